@@ -2,11 +2,20 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // AtlassianClient represents the client for interacting with Atlassian APIs
@@ -18,8 +27,68 @@ type AtlassianClient struct {
 	OrgId        string
 	BaseURL      string
 	HTTPClient   *http.Client
+
+	// MaxRetries is the number of times a throttled or unavailable request is
+	// retried; RetryWaitMin and RetryWaitMax bound the backoff between attempts
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	jitter       *jitterSource
+	retryBudget  *retryBudget
+	// sleeper waits between attempts; nil uses sleepContext. Tests replace it
+	// to record backoffs without waiting.
+	sleeper func(ctx context.Context, d time.Duration) error
+
+	// RetryPolicies configures retries per status class (RetryClassThrottled,
+	// RetryClassServerError); missing classes use defaultRetryPolicies
+	RetryPolicies map[string]RetryPolicy
+
+	// OnRetry, if set, is called before every retry with the number of the
+	// attempt that failed (starting at 0) and its status, or 0 and the error
+	// for network errors. It is meant for programs using the client directly,
+	// e.g. to count retries, and must be safe for concurrent use.
+	OnRetry func(attempt int, status int, err error)
+
+	// DefaultTeamType is used by resources that do not set a team type
+	DefaultTeamType string
+
+	// ExposeRawJSON makes resources and data sources record the raw JSON of
+	// the last team response in state, for troubleshooting API changes
+	ExposeRawJSON bool
+
+	// TreatMissingAsDeleted makes resources drop objects the API reports as
+	// not found from state on refresh, instead of failing the refresh
+	TreatMissingAsDeleted bool
+
+	// RequireSiteId keeps team reads scoped to the site: a team the site does
+	// not find is not looked up again without the site scope
+	RequireSiteId bool
+
+	// TeamsAPIVersion is the version segment of the Teams API paths, e.g. v1
+	TeamsAPIVersion string
+
+	// DefaultAcceptHeader, if set, replaces the Accept header of every request,
+	// for gateways that mishandle the per-endpoint values
+	DefaultAcceptHeader string
+
+	// ExtraHeaders are added to every request, e.g. to opt in to experimental
+	// endpoints. Headers set per endpoint take precedence; Authorization is ignored.
+	ExtraHeaders map[string]string
+
+	// OAuthTokenURL overrides the OAuth token endpoint used by SetOAuthCredentials.
+	// When tokenSource is set its access tokens replace APIToken.
+	OAuthTokenURL string
+	tokenSource   *oauthTokenSource
+
+	// ctx is attached to outgoing requests and used for logging, see WithContext
+	ctx context.Context
 }
 
+// ErrTeamDeleteConflict is returned by DeleteTeam when the API refuses to delete
+// a team in its current state (e.g. an archived team in orgs that require it to
+// be unarchived first)
+var ErrTeamDeleteConflict = errors.New("team cannot be deleted in its current state")
+
 // Team represents an Atlassian Team (matches PublicApiTeam schema)
 type Team struct {
 	TeamID         string `json:"teamId"`
@@ -41,6 +110,20 @@ type TeamResponse struct {
 	CreatorId       string           `json:"creatorId,omitempty"`
 	State           string           `json:"state"`
 	UserPermissions *UserPermissions `json:"userPermissions"`
+
+	// RawJSON is the response body the team was decoded from
+	RawJSON json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a team and keeps the body in RawJSON, so responses
+// can be inspected when they do not match the expected shape
+func (t *TeamResponse) UnmarshalJSON(data []byte) error {
+	type plain TeamResponse
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	t.RawJSON = slices.Clone(data)
+	return nil
 }
 
 // TeamResponseWithMembers represents team with members (matches PublicApiTeamResponseWithMembers)
@@ -54,6 +137,19 @@ type TeamResponseWithMembers struct {
 	State           string           `json:"state"`
 	Members         []TeamMember     `json:"members"`
 	UserPermissions *UserPermissions `json:"userPermissions"`
+
+	// RawJSON is the body of the team response, see TeamResponse.RawJSON
+	RawJSON json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a team and keeps the body in RawJSON
+func (t *TeamResponseWithMembers) UnmarshalJSON(data []byte) error {
+	type plain TeamResponseWithMembers
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	t.RawJSON = slices.Clone(data)
+	return nil
 }
 
 // UserPermissions represents team permissions
@@ -67,6 +163,10 @@ type UserPermissions struct {
 // TeamMember represents a team member (matches PublicApiMembership)
 type TeamMember struct {
 	AccountID string `json:"accountId"`
+
+	// MemberType is the member_type configured on the team resource. The API
+	// has no member types, so it is never sent.
+	MemberType string `json:"-"`
 }
 
 // CreateTeamRequest represents the request to create a team (matches PublicApiTeamCreationPayload)
@@ -79,8 +179,8 @@ type CreateTeamRequest struct {
 
 // UpdateTeamRequest represents the request to update a team (matches PublicApiTeamUpdatePayload)
 type UpdateTeamRequest struct {
-	DisplayName string `json:"displayName,omitempty"` // maxLength: 250, minLength: 1, pattern: .*\\S+.*
-	Description string `json:"description,omitempty"` // maxLength: 360, minLength: 0
+	DisplayName string  `json:"displayName,omitempty"` // maxLength: 250, minLength: 1, pattern: .*\\S+.*
+	Description *string `json:"description,omitempty"` // maxLength: 360, minLength: 0; nil leaves it unchanged, "" clears it
 }
 
 // NewAtlassianClient creates a new Atlassian API client
@@ -91,37 +191,248 @@ func NewAtlassianClient(apiToken, email, organization, siteId, orgId, baseURL st
 		Organization: organization,
 		SiteId:       siteId,
 		OrgId:        orgId,
-		BaseURL:      baseURL,
+		BaseURL:      strings.TrimRight(baseURL, "/"),
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
+			// Cloned so TLS settings stay per-client; keeps ProxyFromEnvironment,
+			// which honors HTTPS_PROXY and NO_PROXY
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
 		},
+		MaxRetries:      3,
+		RetryWaitMin:    1 * time.Second,
+		RetryWaitMax:    30 * time.Second,
+		jitter:          newJitterSource(),
+		RetryPolicies:   defaultRetryPolicies(),
+		TeamsAPIVersion: defaultTeamsAPIVersion,
+
+		TreatMissingAsDeleted: true,
 	}, nil
 }
 
-// makeRequest makes an HTTP request to the Atlassian API
+// transport returns the client's *http.Transport, installing a clone of
+// http.DefaultTransport first if none is configured
+func (c *AtlassianClient) transport() *http.Transport {
+	if t, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+		return t
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	c.HTTPClient.Transport = t
+	return t
+}
+
+// tlsConfig returns the TLS configuration of the client's transport, creating it if needed
+func (c *AtlassianClient) tlsConfig() *tls.Config {
+	t := c.transport()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return t.TLSClientConfig
+}
+
+// SetInsecureSkipVerify disables TLS certificate verification. Only meant for
+// test or staging endpoints with self-signed certificates.
+func (c *AtlassianClient) SetInsecureSkipVerify(skip bool) {
+	c.tlsConfig().InsecureSkipVerify = skip
+}
+
+// SetCACertFile trusts the PEM-encoded CA certificates in filename in addition
+// to the system roots
+func (c *AtlassianClient) SetCACertFile(filename string) error {
+	pemData, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("error reading CA certificate file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return fmt.Errorf("no PEM-encoded certificates found in %s", filename)
+	}
+
+	c.tlsConfig().RootCAs = pool
+	return nil
+}
+
+// WithContext returns a shallow copy of the client whose requests use ctx for
+// cancellation and logging
+func (c *AtlassianClient) WithContext(ctx context.Context) *AtlassianClient {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// WithOrgId returns a shallow copy of the client whose team calls target
+// orgId instead of the provider organization. An empty orgId returns c.
+func (c *AtlassianClient) WithOrgId(orgId string) *AtlassianClient {
+	if orgId == "" {
+		return c
+	}
+	clone := *c
+	clone.OrgId = orgId
+	return &clone
+}
+
+// WithAPIToken returns a shallow copy of the client that authenticates with
+// token, e.g. a scoped read-only token for data sources. An empty token
+// returns c. OAuth credentials take precedence over the token.
+func (c *AtlassianClient) WithAPIToken(token string) *AtlassianClient {
+	if token == "" || token == c.APIToken {
+		return c
+	}
+	clone := *c
+	clone.APIToken = token
+	return &clone
+}
+
+// context returns the client's context, defaulting to context.Background()
+func (c *AtlassianClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// Accept header values declared by the API endpoints
+const (
+	acceptJSON = "application/json"
+	acceptAny  = "*/*" // membership endpoints declare */* responses in the OpenAPI spec
+)
+
+// makeRequest makes an HTTP request to the Atlassian API expecting a JSON response
 func (c *AtlassianClient) makeRequest(method, path string, body interface{}) (*http.Response, error) {
-	return c.makeRequestWithHeaders(method, path, body, nil)
+	return c.makeRequestAccepting(method, path, body, acceptJSON)
 }
 
-// makeRequestWithHeaders makes an HTTP request with custom headers
-func (c *AtlassianClient) makeRequestWithHeaders(method, path string, body interface{}, customHeaders map[string]string) (*http.Response, error) {
-	var reqBody io.Reader
+// makeRequestAccepting makes an HTTP request with the Accept header the
+// endpoint declares, unless DefaultAcceptHeader overrides it
+func (c *AtlassianClient) makeRequestAccepting(method, path string, body interface{}, accept string) (*http.Response, error) {
+	return c.makeRequestWithHeaders(method, path, body, map[string]string{"Accept": accept}, method != http.MethodPost)
+}
+
+// makeReadRequestAccepting is like makeRequestAccepting for requests that only
+// read, so they are safe to repeat even when the endpoint takes a POST
+func (c *AtlassianClient) makeReadRequestAccepting(method, path string, body interface{}, accept string) (*http.Response, error) {
+	return c.makeRequestWithHeaders(method, path, body, map[string]string{"Accept": accept}, true)
+}
+
+// makeRequestWithHeaders makes an HTTP request with custom headers, retrying
+// throttled and unavailable responses with backoff (see client_retry.go).
+// Responses that may come after the API already acted are only retried for
+// idempotent requests.
+func (c *AtlassianClient) makeRequestWithHeaders(method, path string, body interface{}, customHeaders map[string]string, idempotent bool) (*http.Response, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	fullURL := c.BaseURL + path
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	ctx := c.context()
+	refreshedToken := false
+
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, path, jsonBody, customHeaders)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err == nil {
+			if err := decompressResponse(resp); err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("error decompressing response: %w", err)
+			}
+			err = bufferResponseBody(resp)
+		}
+		if err != nil {
+			if !c.retryNetworkError(ctx, method, path, idempotent, attempt, err) {
+				return nil, fmt.Errorf("error making request: %w", err)
+			}
+			continue
+		}
+
+		// An OAuth access token may be revoked or expire early, refresh it once
+		if resp.StatusCode == http.StatusUnauthorized && c.tokenSource != nil && !refreshedToken {
+			refreshedToken = true
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			tflog.Debug(ctx, "Refreshing OAuth access token after 401", map[string]any{"method": method, "path": path})
+			c.tokenSource.invalidate(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+			attempt--
+			continue
+		}
+
+		tflog.Debug(ctx, "Atlassian API response", map[string]any{
+			"method":     method,
+			"path":       path,
+			"status":     resp.StatusCode,
+			"request_id": requestID(resp.Header),
+		})
+
+		rateLimit := parseRateLimit(resp.Header)
+		logRateLimit(ctx, method, path, rateLimit)
+
+		policy := c.retryPolicy(resp.StatusCode)
+		if attempt >= c.MaxRetries || !policy.Enabled || !shouldRetry(idempotent, &APIError{StatusCode: resp.StatusCode}) {
+			return resp, nil
+		}
+
+		if !policy.HonorRetryAfter {
+			rateLimit.RetryAfter = ""
+		}
+		wait := c.retryWait(attempt, rateLimit)
+		if !c.retryBudget.take(wait) {
+			tflog.Warn(ctx, "Retry budget exhausted, not retrying Atlassian API request", map[string]any{
+				"method": method,
+				"path":   path,
+				"status": resp.StatusCode,
+			})
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		tflog.Debug(ctx, "Retrying Atlassian API request", map[string]any{
+			"method":  method,
+			"path":    path,
+			"status":  resp.StatusCode,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+		c.notifyRetry(attempt, resp.StatusCode, nil)
+
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("error making request: %w", err)
+		}
+	}
+}
+
+// newRequest builds a single HTTP request attempt. The body is re-read from
+// jsonBody so that every retry sends the full payload.
+func (c *AtlassianClient) newRequest(ctx context.Context, method, path string, jsonBody []byte, customHeaders map[string]string) (*http.Request, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.requestURL(path), reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	// Set authentication headers
-	if c.Email != "" {
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if c.Email != "" {
 		req.SetBasicAuth(c.Email, c.APIToken)
 	} else {
 		// Use Bearer token for Teams API if no email provided (e.g. Org API token)
@@ -129,22 +440,36 @@ func (c *AtlassianClient) makeRequestWithHeaders(method, path string, body inter
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	// Set default Accept header unless custom header provided
-	if customHeaders == nil || customHeaders["Accept"] == "" {
-		req.Header.Set("Accept", "application/json")
+	// Listings can be large, decompressResponse decodes gzip bodies
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	// Default to JSON, endpoints declaring another type pass it as a custom header
+	req.Header.Set("Accept", acceptJSON)
+
+	for key, value := range c.ExtraHeaders {
+		if !strings.EqualFold(key, "Authorization") {
+			req.Header.Set(key, value)
+		}
 	}
 
 	// Set custom headers
 	for key, value := range customHeaders {
-		req.Header.Set(key, value)
+		if value != "" {
+			req.Header.Set(key, value)
+		}
 	}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+	if c.DefaultAcceptHeader != "" {
+		req.Header.Set("Accept", c.DefaultAcceptHeader)
 	}
 
-	return resp, nil
+	return req, nil
+}
+
+// requestURL joins BaseURL and path with exactly one slash, since gateways
+// may reject the "//" produced by a base URL with a trailing slash
+func (c *AtlassianClient) requestURL(path string) string {
+	return strings.TrimRight(c.BaseURL, "/") + "/" + strings.TrimLeft(path, "/")
 }
 
 // CreateTeam creates a new team in Atlassian
@@ -156,38 +481,73 @@ func (c *AtlassianClient) CreateTeam(team *CreateTeamRequest) (*TeamResponseWith
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error creating team: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("creating team", resp)
 	}
 
 	var createdTeam TeamResponseWithMembers
-	if err := json.NewDecoder(resp.Body).Decode(&createdTeam); err != nil {
+	if err := decodeResponse(resp, &createdTeam); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
+	if createdTeam.TeamID == "" {
+		return nil, fmt.Errorf("error creating team: response did not include a team ID")
+	}
 
 	return &createdTeam, nil
 }
 
-// GetTeam retrieves a team by ID
+// GetTeam retrieves a team by ID, scoped to the client's site if configured
 func (c *AtlassianClient) GetTeam(teamID string) (*TeamResponse, error) {
-	path := c.getTeamAPIPathWithQuery("/teams/"+teamID, c.SiteId)
+	return c.GetTeamInSite(teamID, c.SiteId)
+}
+
+// GetTeamInSite retrieves a team by ID scoped to siteId, or the client's
+// SiteId if it is empty. If the site-scoped request returns 404 it is retried
+// once without a site scope, since teams not tied to the site are otherwise
+// reported as missing, unless RequireSiteId is set.
+func (c *AtlassianClient) GetTeamInSite(teamID, siteId string) (*TeamResponse, error) {
+	team, _, err := c.getTeamInSite(teamID, siteId)
+	return team, err
+}
+
+// getTeamInSite is GetTeamInSite, additionally returning the site scope the
+// team was found in: siteId, or "" if it was only found without a scope
+func (c *AtlassianClient) getTeamInSite(teamID, siteId string) (*TeamResponse, string, error) {
+	siteId = c.siteIdOrDefault(siteId)
+	team, err := c.getTeam(teamID, siteId)
+	if !c.fallBackWithoutSite(siteId, err) {
+		if err == nil {
+			tflog.Debug(c.context(), "Found team", map[string]any{"team_id": teamID, "site_id": siteId})
+		}
+		return team, siteId, err
+	}
+
+	team, err = c.getTeam(teamID, "")
+	if err == nil {
+		tflog.Debug(c.context(), "Found team without site scope", map[string]any{"team_id": teamID, "site_id": siteId})
+	}
+	return team, "", err
+}
+
+// fallBackWithoutSite reports whether a read scoped to siteId that failed
+// with err is retried without the site scope
+func (c *AtlassianClient) fallBackWithoutSite(siteId string, err error) bool {
+	return siteId != "" && !c.RequireSiteId && isNotFoundError(err)
+}
+
+func (c *AtlassianClient) getTeam(teamID, siteId string) (*TeamResponse, error) {
+	path := c.getTeamAPIPathWithQuery("/teams/"+teamID, siteId)
 	resp, err := c.makeRequest("GET", path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error getting team: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("team not found: %s", teamID)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error getting team: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("getting team", resp)
 	}
 
 	var team TeamResponse
-	if err := json.NewDecoder(resp.Body).Decode(&team); err != nil {
+	if err := decodeResponse(resp, &team); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
 
@@ -202,17 +562,12 @@ func (c *AtlassianClient) UpdateTeam(teamID string, updateReq *UpdateTeamRequest
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("team not found: %s", teamID)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error updating team: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("updating team", resp)
 	}
 
 	var updatedTeam TeamResponse
-	if err := json.NewDecoder(resp.Body).Decode(&updatedTeam); err != nil {
+	if err := decodeResponse(resp, &updatedTeam); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
 
@@ -221,7 +576,49 @@ func (c *AtlassianClient) UpdateTeam(teamID string, updateReq *UpdateTeamRequest
 
 // DeleteTeam deletes a team
 func (c *AtlassianClient) DeleteTeam(teamID string) error {
-	resp, err := c.makeRequest("DELETE", c.getTeamAPIPath("/teams/"+teamID), nil)
+	return c.deleteTeamInOrg(c.getOrgIdentifier(), teamID)
+}
+
+// maxTeamDeleteConflictRetries is how often DeleteTeamRetryingConflicts
+// retries a delete the API refused with 409 Conflict
+const maxTeamDeleteConflictRetries = 4
+
+// DeleteTeamRetryingConflicts is like DeleteTeam, but retries a delete
+// refused with 409 Conflict with exponential backoff from RetryWaitMin, since
+// the API refuses to delete a team while removing its members is pending. The
+// waits count against the retry budget.
+func (c *AtlassianClient) DeleteTeamRetryingConflicts(teamID string) error {
+	for attempt := 0; ; attempt++ {
+		err := c.DeleteTeam(teamID)
+		if attempt == maxTeamDeleteConflictRetries || !hasStatusCode(err, http.StatusConflict) {
+			return err
+		}
+
+		wait := min(c.RetryWaitMin<<attempt, c.RetryWaitMax)
+		if !c.retryBudget.take(wait) {
+			tflog.Warn(c.context(), "Retry budget exhausted, not retrying team delete after conflict", map[string]any{
+				"team_id": teamID,
+				"attempt": attempt + 1,
+			})
+			return err
+		}
+		tflog.Debug(c.context(), "Retrying team delete after conflict", map[string]any{
+			"team_id": teamID,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+		c.notifyRetry(attempt, http.StatusConflict, err)
+		if err := c.sleep(c.context(), wait); err != nil {
+			return err
+		}
+	}
+}
+
+// deleteTeamInOrg deletes a team belonging to the given organization
+func (c *AtlassianClient) deleteTeamInOrg(orgID, teamID string) error {
+	path := c.teamsOrgPath(orgID, "/teams/"+teamID)
+
+	resp, err := c.makeRequest("DELETE", path, nil)
 	if err != nil {
 		return fmt.Errorf("error deleting team: %w", err)
 	}
@@ -232,24 +629,59 @@ func (c *AtlassianClient) DeleteTeam(teamID string) error {
 		return nil
 	}
 
+	if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("%w: %w", ErrTeamDeleteConflict, newAPIError("deleting team", resp))
+	}
+
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error deleting team: %s - %s", resp.Status, string(body))
+		return newAPIError("deleting team", resp)
 	}
 
 	return nil
 }
 
-// getTeamAPIPath returns the appropriate API path for team operations
-// This uses the organization ID for public team APIs
+// defaultTeamsAPIVersion is the Teams API version used when none is configured
+const defaultTeamsAPIVersion = "v1"
+
 // getTeamAPIPath returns the appropriate API path for team operations
 // This uses the organization ID for public team APIs
 func (c *AtlassianClient) getTeamAPIPath(endpoint string) string {
-	orgIdentifier := c.OrgId
-	if orgIdentifier == "" {
-		orgIdentifier = c.Organization
+	return c.teamsOrgPath(c.getOrgIdentifier(), endpoint)
+}
+
+// teamsOrgPath returns the Teams API path of endpoint in the given organization,
+// using the configured API version
+func (c *AtlassianClient) teamsOrgPath(orgID, endpoint string) string {
+	version := c.TeamsAPIVersion
+	if version == "" {
+		version = defaultTeamsAPIVersion
+	}
+	return fmt.Sprintf("/public/teams/%s/org/%s%s", version, orgID, endpoint)
+}
+
+// getOrgIdentifier returns the org ID, falling back to the legacy organization name
+func (c *AtlassianClient) getOrgIdentifier() string {
+	if c.OrgId != "" {
+		return c.OrgId
+	}
+	return c.Organization
+}
+
+// withoutSiteId returns a shallow copy of the client whose reads are not
+// scoped to the provider site_id
+func (c *AtlassianClient) withoutSiteId() *AtlassianClient {
+	clone := *c
+	clone.SiteId = ""
+	return &clone
+}
+
+// siteIdOrDefault returns siteId, or the client's SiteId if it is empty, so
+// reads are scoped to the provider site_id without callers passing it
+func (c *AtlassianClient) siteIdOrDefault(siteId string) string {
+	if siteId != "" {
+		return siteId
 	}
-	return fmt.Sprintf("/public/teams/v1/org/%s%s", orgIdentifier, endpoint)
+	return c.SiteId
 }
 
 // getTeamAPIPathWithQuery returns the API path with optional query parameters
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError is returned by client methods when the API responds with a non-2xx status
+type APIError struct {
+	Operation  string // e.g. "getting team"
+	StatusCode int
+	Status     string
+	Body       string
+	RequestID  string // Atlassian request ID, quote it when contacting Atlassian support
+
+	// Code and Message are decoded from the response body when it is a JSON error object
+	Code    string
+	Message string
+}
+
+// apiErrorBody matches the error payload returned by Atlassian APIs
+type apiErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// maxResponseBodySize bounds how much of a response body is read, so a
+// misbehaving gateway cannot exhaust memory with a huge page
+const maxResponseBodySize = 10 << 20
+
+// ErrResponseBodyTooLarge is returned when a response body exceeds maxResponseBodySize
+var ErrResponseBodyTooLarge = fmt.Errorf("response body exceeds %d MiB", maxResponseBodySize>>20)
+
+// readResponseBody reads at most maxResponseBodySize bytes of body. Larger
+// bodies return the bytes read so far together with ErrResponseBodyTooLarge.
+func readResponseBody(body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxResponseBodySize+1))
+	if err != nil {
+		return data, err
+	}
+	if len(data) > maxResponseBodySize {
+		return data[:maxResponseBodySize], ErrResponseBodyTooLarge
+	}
+	return data, nil
+}
+
+// newAPIError builds an APIError from a response, consuming its body. Bodies
+// over the size limit are truncated.
+func newAPIError(operation string, resp *http.Response) *APIError {
+	body, _ := readResponseBody(resp.Body)
+
+	apiErr := &APIError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+		RequestID:  requestID(resp.Header),
+	}
+
+	var decoded apiErrorBody
+	if json.Unmarshal(body, &decoded) == nil {
+		apiErr.Code = decoded.Code
+		apiErr.Message = decoded.Message
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("API error %s: %s - %s (Atlassian request ID: %s)", e.Operation, e.Status, e.Body, e.RequestID)
+	}
+	return fmt.Sprintf("API error %s: %s - %s", e.Operation, e.Status, e.Body)
+}
+
+// decodeResponse decodes a JSON response body into v. Responses without a
+// body (204 No Content or an empty body) leave v unchanged. Bodies that are
+// not JSON, such as HTML error pages from gateways, produce an error that
+// names the content type.
+func decodeResponse(resp *http.Response, v any) error {
+	if resp.StatusCode == http.StatusNoContent || resp.ContentLength == 0 {
+		return nil
+	}
+
+	body, err := readResponseBody(resp.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unexpected %q response body: %w", resp.Header.Get("Content-Type"), err)
+	}
+	return nil
+}
+
+// requestID returns the Atlassian request ID of a response, falling back to the trace ID
+func requestID(header http.Header) string {
+	if id := header.Get("X-Arequestid"); id != "" {
+		return id
+	}
+	return header.Get("Atl-Traceid")
+}
+
+// isNotFoundError reports whether err is an APIError with a 404 status
+func isNotFoundError(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// missingHint explains a not found error that was kept as an error because
+// treat_missing_as_deleted is disabled
+func missingHint(err error) string {
+	if !isNotFoundError(err) {
+		return ""
+	}
+	return "\n\nThe object was not found, but treat_missing_as_deleted is false, so it is kept in state. " +
+		"If it was deleted outside Terraform, remove it with terraform state rm."
+}
+
+// permissionHint explains a 403, which on first use usually means the
+// credentials are valid but not allowed to administer the organization
+func permissionHint(err error) string {
+	if !hasStatusCode(err, http.StatusForbidden) {
+		return ""
+	}
+	return "\n\nThe Atlassian API refused the request. The credentials are valid but lack the permission for it: " +
+		"use an organization admin API key created in Atlassian Administration, or an OAuth token with the " +
+		"read:team:atlassian and write:team:atlassian scopes, and check that it belongs to the configured organization. " +
+		"See https://support.atlassian.com/organization-administration/docs/manage-an-organization-with-the-admin-apis/"
+}
+
+// hasStatusCode reports whether err is an APIError with the given status code
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
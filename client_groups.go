@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// groupMemberPage matches the paged response of the Jira group member endpoint
+type groupMemberPage struct {
+	IsLast bool `json:"isLast"`
+	Values []struct {
+		AccountID string `json:"accountId"`
+	} `json:"values"`
+}
+
+// groupUserPayload matches the request body of the Jira add user to group endpoint
+type groupUserPayload struct {
+	AccountID string `json:"accountId"`
+}
+
+// getGroupAPIPath returns the path of a Jira group endpoint of a site
+func getGroupAPIPath(siteId, endpoint string, query url.Values) string {
+	return fmt.Sprintf("/ex/jira/%s/rest/api/3%s?%s", url.PathEscape(siteId), endpoint, query.Encode())
+}
+
+// GetGroupMembers returns the account IDs of all members of a Jira group,
+// including inactive users
+func (c *AtlassianClient) GetGroupMembers(siteId, groupID string) ([]string, error) {
+	accountIDs := []string{}
+	const pageSize = 50
+
+	for startAt := 0; ; startAt += pageSize {
+		query := url.Values{
+			"groupId":              {groupID},
+			"includeInactiveUsers": {"true"},
+			"startAt":              {fmt.Sprint(startAt)},
+			"maxResults":           {fmt.Sprint(pageSize)},
+		}
+
+		resp, err := c.makeRequest("GET", getGroupAPIPath(siteId, "/group/member", query), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting group members: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			apiErr := newAPIError("getting group members", resp)
+			resp.Body.Close()
+			return nil, apiErr
+		}
+
+		var page groupMemberPage
+		err = decodeResponse(resp, &page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding group members response: %w", err)
+		}
+
+		for _, member := range page.Values {
+			accountIDs = append(accountIDs, member.AccountID)
+		}
+		if page.IsLast || len(page.Values) == 0 {
+			return accountIDs, nil
+		}
+	}
+}
+
+// groupMemberNotAttempted is the error code of accounts a group member batch
+// did not get to because an earlier request could not be made
+const groupMemberNotAttempted = "NOT_ATTEMPTED"
+
+// notAttemptedGroupMembers returns member errors for accountIDs that were left
+// over when a batch stopped
+func notAttemptedGroupMembers(accountIDs []string, err error) []PublicApiMembershipCodedError {
+	memberErrors := make([]PublicApiMembershipCodedError, len(accountIDs))
+	for i, accountID := range accountIDs {
+		memberErrors[i] = PublicApiMembershipCodedError{AccountID: accountID, Code: groupMemberNotAttempted, Message: err.Error()}
+	}
+	return memberErrors
+}
+
+// AddGroupMembers adds accounts to a Jira group. The groups API takes one
+// account per call, so failures are collected per account like the errors of
+// AddTeamMembers; the returned error is only set if a request could not be made.
+// The batch then stops, and the accounts it did not get to are returned as
+// errors with code NOT_ATTEMPTED, so every account without an error was added.
+func (c *AtlassianClient) AddGroupMembers(siteId, groupID string, accountIDs []string) ([]PublicApiMembershipCodedError, error) {
+	var memberErrors []PublicApiMembershipCodedError
+
+	for i, accountID := range accountIDs {
+		query := url.Values{"groupId": {groupID}}
+		resp, err := c.makeRequest("POST", getGroupAPIPath(siteId, "/group/user", query), groupUserPayload{AccountID: accountID})
+		if err != nil {
+			err = fmt.Errorf("error adding group member: %w", err)
+			return append(memberErrors, notAttemptedGroupMembers(accountIDs[i:], err)...), err
+		}
+
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+			apiErr := newAPIError("adding group member", resp)
+			memberErrors = append(memberErrors, PublicApiMembershipCodedError{AccountID: accountID, Code: apiErr.Status, Message: apiErr.Body})
+		}
+		resp.Body.Close()
+	}
+
+	return memberErrors, nil
+}
+
+// RemoveGroupMembers removes accounts from a Jira group, one call per account.
+// Accounts that are not members are treated as removed. Errors are reported
+// like for AddGroupMembers.
+func (c *AtlassianClient) RemoveGroupMembers(siteId, groupID string, accountIDs []string) ([]PublicApiMembershipCodedError, error) {
+	var memberErrors []PublicApiMembershipCodedError
+
+	for i, accountID := range accountIDs {
+		query := url.Values{"groupId": {groupID}, "accountId": {accountID}}
+		resp, err := c.makeRequest("DELETE", getGroupAPIPath(siteId, "/group/user", query), nil)
+		if err != nil {
+			err = fmt.Errorf("error removing group member: %w", err)
+			return append(memberErrors, notAttemptedGroupMembers(accountIDs[i:], err)...), err
+		}
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			apiErr := newAPIError("removing group member", resp)
+			memberErrors = append(memberErrors, PublicApiMembershipCodedError{AccountID: accountID, Code: apiErr.Status, Message: apiErr.Body})
+		}
+		resp.Body.Close()
+	}
+
+	return memberErrors, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipBody decompresses a gzip-encoded response body and closes the
+// underlying body with it
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.body.Close())
+}
+
+// decompressResponse replaces a gzip-encoded response body with its
+// decompressed content. Requests ask for gzip explicitly, which turns off the
+// transparent decompression of http.Transport, so every response is passed
+// through here; custom transports that leave bodies encoded are covered too.
+func decompressResponse(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	switch {
+	case errors.Is(err, io.EOF):
+		// Empty body, e.g. a 204 from a gateway that marks every response as gzip
+		resp.Body.Close()
+		resp.Body = http.NoBody
+	case err != nil:
+		return err
+	default:
+		resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
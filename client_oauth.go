@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultOAuthTokenURL is the Atlassian OAuth 2.0 (3LO) token endpoint
+const defaultOAuthTokenURL = "https://auth.atlassian.com/oauth/token"
+
+// oauthExpiryMargin refreshes access tokens shortly before they expire so a
+// token does not run out between being read and the request reaching the API
+const oauthExpiryMargin = time.Minute
+
+// oauthTokenRequest is the refresh_token grant payload of the token endpoint
+type oauthTokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// oauthTokenResponse is the response of the token endpoint
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+}
+
+// oauthTokenSource exchanges a refresh token for access tokens and caches
+// them until they expire. It is shared by all copies of a client.
+type oauthTokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+
+	mu           sync.Mutex
+	refreshToken string
+	accessToken  string
+	expiry       time.Time
+}
+
+// token returns a valid access token, refreshing it if needed
+func (s *oauthTokenSource) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && time.Now().Before(s.expiry.Add(-oauthExpiryMargin)) {
+		return s.accessToken, nil
+	}
+	if err := s.refresh(ctx); err != nil {
+		return "", err
+	}
+	return s.accessToken, nil
+}
+
+// invalidate drops the cached access token if it is still stale, so the next
+// call to token refreshes it
+func (s *oauthTokenSource) invalidate(stale string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken == stale {
+		s.accessToken = ""
+	}
+}
+
+// refresh exchanges the refresh token for a new access token. Atlassian
+// rotates refresh tokens, so the returned one replaces the current one.
+// Callers must hold s.mu.
+func (s *oauthTokenSource) refresh(ctx context.Context) error {
+	body, err := json.Marshal(oauthTokenRequest{
+		GrantType:    "refresh_token",
+		ClientID:     s.clientID,
+		ClientSecret: s.clientSecret,
+		RefreshToken: s.refreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling OAuth token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating OAuth token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error refreshing OAuth access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError("refreshing OAuth access token", resp)
+	}
+
+	var tokenResp oauthTokenResponse
+	if err := decodeResponse(resp, &tokenResp); err != nil {
+		return fmt.Errorf("error decoding OAuth token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return fmt.Errorf("OAuth token response did not contain an access token")
+	}
+
+	s.accessToken = tokenResp.AccessToken
+	s.expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	if tokenResp.RefreshToken != "" {
+		s.refreshToken = tokenResp.RefreshToken
+	}
+
+	tflog.Debug(ctx, "Refreshed OAuth access token", map[string]any{"expires_in": tokenResp.ExpiresIn, "scope": tokenResp.Scope})
+	return nil
+}
+
+// SetOAuthCredentials switches the client to OAuth 2.0 (3LO) bearer tokens.
+// The refresh token is exchanged for an access token right away so bad
+// credentials fail early; later tokens are refreshed on expiry or on a 401.
+func (c *AtlassianClient) SetOAuthCredentials(ctx context.Context, clientID, clientSecret, refreshToken string) error {
+	tokenURL := c.OAuthTokenURL
+	if tokenURL == "" {
+		tokenURL = defaultOAuthTokenURL
+	}
+
+	source := &oauthTokenSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		refreshToken: refreshToken,
+		httpClient:   c.HTTPClient,
+	}
+	if _, err := source.token(ctx); err != nil {
+		return err
+	}
+
+	c.tokenSource = source
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Organization represents an Atlassian organization
+type Organization struct {
+	ID   string
+	Name string
+	Type string
+}
+
+// organizationResponse matches the response of the Admin API organization endpoint
+type organizationResponse struct {
+	Data struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Name string `json:"name"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// GetOrganization retrieves an organization through the Admin API. The
+// endpoint only exposes the ID, the resource type and the name.
+func (c *AtlassianClient) GetOrganization(orgID string) (*Organization, error) {
+	path := fmt.Sprintf("/admin/v1/orgs/%s", url.PathEscape(orgID))
+
+	resp, err := c.makeRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting organization: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("getting organization", resp)
+	}
+
+	var orgResponse organizationResponse
+	if err := decodeResponse(resp, &orgResponse); err != nil {
+		return nil, fmt.Errorf("error decoding organization response: %w", err)
+	}
+
+	return &Organization{
+		ID:   orgResponse.Data.ID,
+		Name: orgResponse.Data.Attributes.Name,
+		Type: orgResponse.Data.Type,
+	}, nil
+}
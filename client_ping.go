@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrAuthenticationFailed is returned by Ping when the API rejects the credentials
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	// ErrPermissionDenied is returned by Ping when the credentials lack access to the Teams API
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrOrgNotFound is returned by Ping when the organization does not exist or is not visible
+	ErrOrgNotFound = errors.New("organization not found")
+)
+
+// Ping checks the credentials and organization by listing a single team.
+// Failures are classified by wrapping ErrAuthenticationFailed,
+// ErrPermissionDenied or ErrOrgNotFound around the API error.
+func (c *AtlassianClient) Ping() error {
+	_, err := c.GetTeams(c.getOrgIdentifier(), "", 1, "", nil)
+	switch {
+	case err == nil:
+		return nil
+	case hasStatusCode(err, http.StatusUnauthorized):
+		return fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+	case hasStatusCode(err, http.StatusForbidden):
+		return fmt.Errorf("%w: %w", ErrPermissionDenied, err)
+	case isNotFoundError(err):
+		return fmt.Errorf("%w: %w", ErrOrgNotFound, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RateLimit holds the rate-limit headers returned by Atlassian APIs
+type RateLimit struct {
+	Limit      int    // X-RateLimit-Limit, -1 if absent
+	Remaining  int    // X-RateLimit-Remaining, -1 if absent
+	RetryAfter string // Retry-After, in seconds or as an HTTP date
+}
+
+// parseRateLimit extracts rate-limit information from response headers
+func parseRateLimit(header http.Header) RateLimit {
+	return RateLimit{
+		Limit:      parseRateLimitHeader(header, "X-RateLimit-Limit"),
+		Remaining:  parseRateLimitHeader(header, "X-RateLimit-Remaining"),
+		RetryAfter: header.Get("Retry-After"),
+	}
+}
+
+func parseRateLimitHeader(header http.Header, key string) int {
+	value, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+	return value
+}
+
+// isLow reports whether less than 10% of the rate limit is remaining
+func (r RateLimit) isLow() bool {
+	return r.Limit > 0 && r.Remaining >= 0 && r.Remaining*10 < r.Limit
+}
+
+// logRateLimit logs the rate-limit state of a response and warns when it runs low
+func logRateLimit(ctx context.Context, method, path string, rateLimit RateLimit) {
+	if rateLimit.Limit < 0 && rateLimit.Remaining < 0 && rateLimit.RetryAfter == "" {
+		return
+	}
+
+	fields := map[string]any{
+		"method":               method,
+		"path":                 path,
+		"rate_limit_limit":     rateLimit.Limit,
+		"rate_limit_remaining": rateLimit.Remaining,
+		"retry_after":          rateLimit.RetryAfter,
+	}
+
+	tflog.Debug(ctx, "Atlassian API rate limit", fields)
+
+	if rateLimit.isLow() {
+		tflog.Warn(ctx, "Atlassian API rate limit is nearly exhausted, consider reducing concurrency with terraform apply -parallelism=N", fields)
+	}
+}
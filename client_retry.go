@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// jitterSource is a per-client random source, safe for concurrent use by
+// resources sharing the client
+type jitterSource struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func newJitterSource() *jitterSource {
+	seed := uint64(time.Now().UnixNano())
+	return &jitterSource{rnd: rand.New(rand.NewPCG(seed, seed>>32))}
+}
+
+// duration returns a random duration in [0, ceiling]
+func (j *jitterSource) duration(ceiling time.Duration) time.Duration {
+	if ceiling <= 0 {
+		return 0
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return time.Duration(j.rnd.Int64N(int64(ceiling) + 1))
+}
+
+// retryBudget bounds the total time spent waiting between retries by a
+// client and its copies, so a broad outage fails fast instead of every
+// request running through its full backoff
+type retryBudget struct {
+	mu        sync.Mutex
+	remaining time.Duration
+}
+
+// take reserves wait from the budget. Once a wait does not fit, the budget
+// is spent and later retries are refused too, even ones without a wait. A
+// nil budget is unlimited.
+func (b *retryBudget) take(wait time.Duration) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining <= 0 || wait > b.remaining {
+		b.remaining = 0
+		return false
+	}
+	b.remaining -= wait
+	return true
+}
+
+// SetMaxRetryDuration limits the total time the client waits between retries
+// over its lifetime. Requests that would exceed it return their last response.
+func (c *AtlassianClient) SetMaxRetryDuration(d time.Duration) {
+	c.retryBudget = &retryBudget{remaining: d}
+}
+
+// isRetryableStatus reports whether a response status indicates a transient
+// failure: throttling or an unavailable or failing gateway
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsRetryable reports whether err is an APIError with a transient status
+// (429, 502, 503, 504) or a transient network error. Client errors such as
+// 400, 401 and 403 are not.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.StatusCode)
+	}
+	return isRetryableNetworkError(err)
+}
+
+// isRetryableNetworkError reports whether err is a connection failure that
+// may succeed when tried again: a reset or dropped connection, or a network
+// timeout. Cancellation and deadlines of the request context are final.
+func isRetryableNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryNetworkError waits before another attempt of a request that failed
+// with a network error, and reports whether it should be retried. Like a 502,
+// a dropped connection may come after the API already acted, so only
+// idempotent requests are retried.
+func (c *AtlassianClient) retryNetworkError(ctx context.Context, method, path string, idempotent bool, attempt int, err error) bool {
+	if attempt >= c.MaxRetries || !idempotent || ctx.Err() != nil || !isRetryableNetworkError(err) {
+		return false
+	}
+
+	wait := c.retryWait(attempt, RateLimit{})
+	if !c.retryBudget.take(wait) {
+		tflog.Warn(ctx, "Retry budget exhausted, not retrying Atlassian API request", map[string]any{
+			"method": method,
+			"path":   path,
+			"error":  err.Error(),
+		})
+		return false
+	}
+
+	tflog.Debug(ctx, "Retrying Atlassian API request after network error", map[string]any{
+		"method":  method,
+		"path":    path,
+		"error":   err.Error(),
+		"attempt": attempt + 1,
+		"wait":    wait.String(),
+	})
+	c.notifyRetry(attempt, 0, err)
+
+	return c.sleep(ctx, wait) == nil
+}
+
+// notifyRetry calls OnRetry if it is set
+func (c *AtlassianClient) notifyRetry(attempt, status int, err error) {
+	if c.OnRetry != nil {
+		c.OnRetry(attempt, status, err)
+	}
+}
+
+// bufferResponseBody reads the whole response body into memory, so that a
+// connection dropped mid-response fails the attempt and can be retried
+// instead of surfacing later while the body is decoded. Bodies over
+// maxResponseBodySize fail with ErrResponseBodyTooLarge.
+func bufferResponseBody(resp *http.Response) error {
+	body, err := readResponseBody(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
+// Status classes of the client's RetryPolicies
+const (
+	RetryClassThrottled   = "429"
+	RetryClassServerError = "5xx"
+)
+
+// RetryPolicy controls the retries of responses of one status class
+type RetryPolicy struct {
+	// Enabled retries the class at all; when false its responses are returned as is
+	Enabled bool
+	// HonorRetryAfter waits as long as a Retry-After header asks, if the
+	// response has one, instead of backing off exponentially
+	HonorRetryAfter bool
+}
+
+// defaultRetryPolicies retries throttled responses as long as Retry-After
+// asks and server errors with exponential backoff
+func defaultRetryPolicies() map[string]RetryPolicy {
+	return map[string]RetryPolicy{
+		RetryClassThrottled:   {Enabled: true, HonorRetryAfter: true},
+		RetryClassServerError: {Enabled: true},
+	}
+}
+
+// retryPolicy returns the policy for the class of statusCode, the default
+// policy if the client has none for it
+func (c *AtlassianClient) retryPolicy(statusCode int) RetryPolicy {
+	class := RetryClassServerError
+	if statusCode == http.StatusTooManyRequests {
+		class = RetryClassThrottled
+	}
+	if policy, ok := c.RetryPolicies[class]; ok {
+		return policy
+	}
+	return defaultRetryPolicies()[class]
+}
+
+// shouldRetry reports whether a request that failed with apiErr can be sent
+// again. 429 and 503 mean the request was not processed. A 502 or 504 may
+// come after the API already acted, so only idempotent requests are retried
+// then: all but POST, which creates teams and adds or removes members, unless
+// the POST only reads like the member listing.
+func shouldRetry(idempotent bool, apiErr *APIError) bool {
+	if !IsRetryable(apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return idempotent
+}
+
+// retryWait returns how long to wait before retry number attempt+1. A
+// Retry-After header takes precedence over exponential backoff. The backoff
+// uses full jitter, a random wait up to the exponential ceiling, so that
+// concurrent requests throttled together do not retry in lockstep.
+func (c *AtlassianClient) retryWait(attempt int, rateLimit RateLimit) time.Duration {
+	if wait, ok := parseRetryAfter(rateLimit.RetryAfter, time.Now()); ok {
+		return min(wait, c.RetryWaitMax)
+	}
+
+	ceiling := c.RetryWaitMin << attempt
+	if ceiling <= 0 || ceiling > c.RetryWaitMax {
+		ceiling = c.RetryWaitMax
+	}
+
+	if c.jitter == nil {
+		return ceiling
+	}
+	return c.jitter.duration(ceiling)
+}
+
+// parseRetryAfter parses a Retry-After value given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d between attempts, using the client's sleeper if set
+func (c *AtlassianClient) sleep(ctx context.Context, d time.Duration) error {
+	if c.sleeper != nil {
+		return c.sleeper(ctx, d)
+	}
+	return sleepContext(ctx, d)
+}
+
+// sleepContext waits for d, returning early with the context's error if ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
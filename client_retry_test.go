@@ -0,0 +1,440 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestMakeRequestRetriesThrottledResponses(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"teamId":"t1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+
+	team, err := client.GetTeam("t1")
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retries, got: %v", err)
+	}
+	if team.TeamID != "t1" || attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestOnRetryIsCalledForEveryRetry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"teamId":"t1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+
+	var retries []int
+	client.OnRetry = func(attempt int, status int, err error) {
+		if status != http.StatusServiceUnavailable || err != nil {
+			t.Errorf("Unexpected retry of attempt %d: status %d, error %v", attempt, status, err)
+		}
+		retries = append(retries, attempt)
+	}
+
+	if _, err := client.GetTeam("t1"); err != nil {
+		t.Fatalf("Expected request to succeed after retries, got: %v", err)
+	}
+	if !slices.Equal(retries, []int{0, 1}) {
+		t.Errorf("Expected OnRetry for attempts 0 and 1, got %v", retries)
+	}
+}
+
+func TestMakeRequestResendsBodyOnRetry(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors":[],"members":[{"accountId":"a"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+
+	if _, err := client.AddTeamMembers("org", "team-1", []TeamMember{{AccountID: "a"}}); err != nil {
+		t.Fatalf("Expected AddTeamMembers to succeed after a retry, got: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[1] != bodies[0] {
+		t.Errorf("Expected the retry to resend the same payload, got %q", bodies)
+	}
+}
+
+func TestMakeRequestStopsBackoffWhenContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = time.Minute
+	client.RetryWaitMax = time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.WithContext(ctx).GetTeam("t1")
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a context canceled error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected GetTeam to return promptly after cancellation, took %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if wait, ok := parseRetryAfter("7", now); !ok || wait != 7*time.Second {
+		t.Errorf("Expected 7s, got %s, %v", wait, ok)
+	}
+	if wait, ok := parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now); !ok || wait != 30*time.Second {
+		t.Errorf("Expected 30s, got %s, %v", wait, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Error("Expected an invalid value to be ignored")
+	}
+}
+
+func TestRetryWaitUsesJitter(t *testing.T) {
+	client, _ := NewAtlassianClient("token", "", "", "", "org", "http://localhost")
+	client.RetryWaitMin = time.Second
+	client.RetryWaitMax = time.Minute
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		wait := client.retryWait(2, RateLimit{Limit: -1, Remaining: -1})
+		if wait < 0 || wait > 4*time.Second {
+			t.Fatalf("Expected wait within [0, 4s], got %s", wait)
+		}
+		seen[wait] = true
+	}
+
+	if len(seen) < 2 {
+		t.Error("Expected successive backoff durations to vary")
+	}
+}
+
+func TestRetryBudgetIsSharedAcrossRequests(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = 10 * time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+	client.jitter = nil
+	client.SetMaxRetryDuration(25 * time.Millisecond)
+
+	// Two retries fit into the budget, the third does not and spends it
+	if _, err := client.GetTeam("t1"); err == nil {
+		t.Fatal("Expected the unavailable response to be returned as an error")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts within the budget, got %d", got)
+	}
+
+	// Copies of the client share the spent budget
+	if _, err := client.WithContext(context.Background()).GetTeam("t1"); err == nil {
+		t.Fatal("Expected the unavailable response to be returned as an error")
+	}
+	if got := attempts.Load(); got != 4 {
+		t.Errorf("Expected no retries once the budget is spent, got %d attempts", got)
+	}
+}
+
+func TestZeroRetryBudgetDisablesRetries(t *testing.T) {
+	client, transport, sleeps := newScriptedClient(t,
+		scriptedResponse{status: http.StatusServiceUnavailable},
+	)
+	client.RetryWaitMin = 0
+	client.RetryWaitMax = 0
+	client.SetMaxRetryDuration(0)
+
+	// Even a retry without a wait does not fit into an empty budget
+	if _, err := client.GetTeam("t1"); err == nil {
+		t.Fatal("Expected the unavailable response to be returned as an error")
+	}
+	if len(transport.requests) != 1 || len(sleeps.waits) != 0 {
+		t.Errorf("Expected a single attempt without waits, got %d attempts and waits %v", len(transport.requests), sleeps.waits)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := map[int]bool{
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusForbidden:           false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: false,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	}
+	for statusCode, want := range tests {
+		err := fmt.Errorf("error getting team: %w", &APIError{StatusCode: statusCode})
+		if got := IsRetryable(err); got != want {
+			t.Errorf("status %d: expected IsRetryable %v, got %v", statusCode, want, got)
+		}
+	}
+	if IsRetryable(errors.New("connection reset")) {
+		t.Error("Expected errors other than APIError not to be retryable")
+	}
+}
+
+func TestMakeRequestRetriesGatewayErrorsOnlyWhenSafe(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = time.Millisecond
+
+	if _, err := client.GetTeam("t1"); err == nil {
+		t.Fatal("Expected GetTeam to fail")
+	}
+	if got := attempts.Load(); got != int32(client.MaxRetries)+1 {
+		t.Errorf("Expected a GET to be retried on 504, got %d attempts", got)
+	}
+
+	attempts.Store(0)
+	if _, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"}); err == nil {
+		t.Fatal("Expected CreateTeam to fail")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected a POST not to be retried on 504, got %d attempts", got)
+	}
+}
+
+func TestIsRetryableNetworkErrors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"connection reset": {&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		"unexpected EOF":   {fmt.Errorf("error reading response body: %w", io.ErrUnexpectedEOF), true},
+		"timeout":          {&url.Error{Op: "Get", URL: "https://api.atlassian.com", Err: &net.DNSError{IsTimeout: true}}, true},
+		"deadline":         {&url.Error{Op: "Get", URL: "https://api.atlassian.com", Err: context.DeadlineExceeded}, false},
+		"canceled":         {&url.Error{Op: "Get", URL: "https://api.atlassian.com", Err: context.Canceled}, false},
+		"other":            {errors.New("tls: bad certificate"), false},
+	}
+	for name, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: expected IsRetryable %v, got %v", name, tt.want, got)
+		}
+	}
+}
+
+func TestMakeRequestRetriesDroppedConnections(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Promise a body, send part of it and drop the connection
+			conn, buf, err := http.NewResponseController(w).Hijack()
+			if err != nil {
+				t.Errorf("Unable to hijack connection: %v", err)
+				return
+			}
+			_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 100\r\n\r\n{\"teamId\":")
+			_ = buf.Flush()
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"teamId":"t1","displayName":"Platform"}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = time.Millisecond
+
+	team, err := client.GetTeam("t1")
+	if err != nil {
+		t.Fatalf("Expected GetTeam to succeed after the dropped connection, got: %v", err)
+	}
+	if team.DisplayName != "Platform" || attempts.Load() != 2 {
+		t.Errorf("Expected the team on the second attempt, got %+v after %d attempts", team, attempts.Load())
+	}
+
+	attempts.Store(0)
+	if _, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"}); err == nil {
+		t.Fatal("Expected CreateTeam to fail on the dropped connection")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected a POST not to be retried after a dropped connection, got %d attempts", got)
+	}
+}
+
+func TestFetchTeamMembersRetriesDroppedConnections(t *testing.T) {
+	client, transport, sleeps := newScriptedClient(t,
+		scriptedResponse{err: syscall.ECONNRESET},
+		scriptedResponse{status: http.StatusBadGateway},
+		scriptedResponse{status: http.StatusOK, body: `{"results":[{"accountId":"a"}],"pageInfo":{"hasNextPage":false}}`},
+	)
+
+	// Listing members is a POST, but it only reads, so it is retried like a GET
+	members, err := client.FetchTeamMembers("org-1", "t1", "", "", 50)
+	if err != nil {
+		t.Fatalf("Expected the member fetch to succeed after retries, got: %v", err)
+	}
+	if len(members.Results) != 1 || len(transport.requests) != 3 || len(sleeps.waits) != 2 {
+		t.Errorf("Expected the members on the third attempt, got %+v after %d attempts", members.Results, len(transport.requests))
+	}
+	for _, req := range transport.requests {
+		if req.Method != http.MethodPost {
+			t.Errorf("Expected every attempt to be a POST, got %s", req.Method)
+		}
+	}
+
+	// Adding and removing members are not safe to repeat, a second attempt would run out of script
+	client, _, _ = newScriptedClient(t, scriptedResponse{err: syscall.ECONNRESET})
+	if _, err := client.AddTeamMembers("org-1", "t1", []TeamMember{{AccountID: "a"}}); err == nil {
+		t.Fatal("Expected AddTeamMembers to fail on the dropped connection")
+	}
+	client, _, _ = newScriptedClient(t, scriptedResponse{status: http.StatusBadGateway})
+	if _, err := client.RemoveTeamMembers("org-1", "t1", []TeamMember{{AccountID: "a"}}); err == nil {
+		t.Fatal("Expected RemoveTeamMembers to fail on the 502")
+	}
+}
+
+func TestMakeRequestRetryPolicies(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+			client.RetryWaitMin = time.Millisecond
+			client.RetryWaitMax = time.Minute
+			client.MaxRetries = 1
+			client.RetryPolicies[RetryClassThrottled] = RetryPolicy{Enabled: status != http.StatusTooManyRequests, HonorRetryAfter: true}
+
+			start := time.Now()
+			_, _ = client.GetTeam("t1")
+			elapsed := time.Since(start)
+
+			if status == http.StatusTooManyRequests && attempts.Load() != 1 {
+				t.Errorf("Expected a disabled policy to stop retries, got %d attempts", attempts.Load())
+			}
+			// Server errors back off exponentially instead of waiting for Retry-After
+			if status == http.StatusServiceUnavailable && (attempts.Load() != 2 || elapsed > 30*time.Second) {
+				t.Errorf("Expected one quick retry, got %d attempts in %s", attempts.Load(), elapsed)
+			}
+		})
+	}
+}
+
+func TestMakeRequestRetryTimings(t *testing.T) {
+	client, transport, sleeps := newScriptedClient(t,
+		scriptedResponse{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"5"}}},
+		scriptedResponse{status: http.StatusServiceUnavailable},
+		scriptedResponse{err: syscall.ECONNRESET},
+		scriptedResponse{status: http.StatusOK, body: `{"teamId":"t1"}`},
+	)
+	client.RetryWaitMin = time.Second
+	client.RetryWaitMax = time.Minute
+
+	team, err := client.GetTeam("t1")
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retries, got: %v", err)
+	}
+	if team.TeamID != "t1" || len(transport.requests) != 4 {
+		t.Errorf("Expected 4 attempts, got %d", len(transport.requests))
+	}
+
+	// Retry-After first, then the exponential ceilings of attempts 1 and 2
+	if want := []time.Duration{5 * time.Second, 2 * time.Second, 4 * time.Second}; !slices.Equal(sleeps.waits, want) {
+		t.Errorf("Expected waits %v, got %v", want, sleeps.waits)
+	}
+}
+
+func TestDeleteTeamRetryingConflicts(t *testing.T) {
+	conflict := scriptedResponse{status: http.StatusConflict, body: `{"code":"CONFLICT","message":"team members are still being removed"}`}
+
+	client, transport, sleeps := newScriptedClient(t, conflict, conflict, scriptedResponse{status: http.StatusNoContent})
+	if err := client.DeleteTeamRetryingConflicts("t1"); err != nil {
+		t.Fatalf("Expected the delete to succeed after retries, got: %v", err)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; len(transport.requests) != 3 || !slices.Equal(sleeps.waits, want) {
+		t.Errorf("Expected 3 attempts with waits %v, got %d attempts with waits %v", want, len(transport.requests), sleeps.waits)
+	}
+
+	// The last conflict is returned once the retries are used up
+	responses := slices.Repeat([]scriptedResponse{conflict}, maxTeamDeleteConflictRetries+1)
+	client, transport, sleeps = newScriptedClient(t, responses...)
+	err := client.DeleteTeamRetryingConflicts("t1")
+	if !errors.Is(err, ErrTeamDeleteConflict) {
+		t.Fatalf("Expected the conflict after the last retry, got: %v", err)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}; len(transport.requests) != 5 || !slices.Equal(sleeps.waits, want) {
+		t.Errorf("Expected 5 attempts with waits %v, got %d attempts with waits %v", want, len(transport.requests), sleeps.waits)
+	}
+}
+
+func TestDeleteTeamRetryingConflictsRespectsRetryBudget(t *testing.T) {
+	conflict := scriptedResponse{status: http.StatusConflict, body: `{"code":"CONFLICT","message":"team members are still being removed"}`}
+
+	client, transport, sleeps := newScriptedClient(t, conflict)
+	client.SetMaxRetryDuration(0)
+
+	// An empty budget leaves no room for the first wait
+	if err := client.DeleteTeamRetryingConflicts("t1"); !errors.Is(err, ErrTeamDeleteConflict) {
+		t.Fatalf("Expected the conflict of the first attempt, got: %v", err)
+	}
+	if len(transport.requests) != 1 || len(sleeps.waits) != 0 {
+		t.Errorf("Expected a single attempt without waits, got %d attempts and waits %v", len(transport.requests), sleeps.waits)
+	}
+}
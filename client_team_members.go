@@ -1,12 +1,30 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
 )
 
+// memberNotFoundCodes are the error codes of removals of accounts that are
+// not members of the team
+var memberNotFoundCodes = []string{"NOT_A_MEMBER", "MEMBER_NOT_FOUND", "USER_NOT_MEMBER", "NOT_FOUND"}
+
+// isMemberNotFound reports whether a removal error only says that the account
+// was not a member, i.e. the removal had nothing to do
+func isMemberNotFound(e PublicApiMembershipCodedError) bool {
+	return slices.ContainsFunc(memberNotFoundCodes, func(code string) bool {
+		return strings.EqualFold(e.Code, code)
+	})
+}
+
 // PublicApiMembershipFetchPayload matches OpenAPI spec
 type PublicApiMembershipFetchPayload struct {
 	After string `json:"after,omitempty"` // Pagination cursor
@@ -53,9 +71,43 @@ type PublicApiMembershipRemoveResponse struct {
 	Errors []PublicApiMembershipCodedError `json:"errors"` // uniqueItems: true
 }
 
-// FetchTeamMembers retrieves team members with optional siteId and pagination
+// maxMembersPerPage is the API maximum of members returned by one fetch
+const maxMembersPerPage = 50
+
+// FetchTeamMembers retrieves up to first team members scoped to siteId, or
+// the client's SiteId if it is empty, starting after the given cursor. The API
+// returns at most 50 members per page, so larger values of first are served by
+// fetching several pages and aggregating them; PageInfo is that of the last
+// page fetched. A first of 0 uses the API default of 50.
 func (c *AtlassianClient) FetchTeamMembers(orgID, teamID, siteId, after string, first int32) (*PublicApiFetchResponsePublicApiMembershipAccountId, error) {
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams/%s/members", orgID, teamID)
+	siteId = c.siteIdOrDefault(siteId)
+	if first <= maxMembersPerPage {
+		return c.fetchTeamMembersPage(orgID, teamID, siteId, after, first)
+	}
+
+	aggregated := &PublicApiFetchResponsePublicApiMembershipAccountId{Results: []TeamMember{}}
+	for remaining := first; remaining > 0; {
+		page, err := c.fetchTeamMembersPage(orgID, teamID, siteId, after, min(remaining, maxMembersPerPage))
+		if err != nil {
+			return nil, err
+		}
+		aggregated.Results = append(aggregated.Results, page.Results...)
+		aggregated.PageInfo = page.PageInfo
+		remaining -= int32(len(page.Results))
+
+		// Stop on a missing or repeated cursor so a misbehaving API cannot loop forever
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" || page.PageInfo.EndCursor == after || len(page.Results) == 0 {
+			break
+		}
+		after = page.PageInfo.EndCursor
+	}
+
+	return aggregated, nil
+}
+
+// fetchTeamMembersPage retrieves a single page of at most 50 team members
+func (c *AtlassianClient) fetchTeamMembersPage(orgID, teamID, siteId, after string, first int32) (*PublicApiFetchResponsePublicApiMembershipAccountId, error) {
+	path := c.teamsOrgPath(orgID, "/teams/"+teamID+"/members")
 	if siteId != "" {
 		path += "?siteId=" + siteId
 	}
@@ -65,86 +117,325 @@ func (c *AtlassianClient) FetchTeamMembers(orgID, teamID, siteId, after string,
 	if after != "" {
 		payload.After = after
 	}
-	if first > 0 && first <= 50 {
+	if first > 0 {
 		payload.First = first
 	}
 
-	// Set Accept header to */* as per OpenAPI spec
-	resp, err := c.makeRequestWithHeaders("POST", path, payload, map[string]string{
-		"Accept": "*/*",
-	})
+	// Listing members is a POST, but it only reads, so it is retried like a GET
+	resp, err := c.makeReadRequestAccepting("POST", path, payload, acceptAny)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching team members: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error fetching team members: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("fetching team members", resp)
 	}
 
 	var membersResponse PublicApiFetchResponsePublicApiMembershipAccountId
-	if err := json.NewDecoder(resp.Body).Decode(&membersResponse); err != nil {
+	if err := decodeResponse(resp, &membersResponse); err != nil {
 		return nil, fmt.Errorf("error decoding team members response: %w", err)
 	}
 
 	return &membersResponse, nil
 }
 
-// AddTeamMembers adds members to a team
+// maxMembersPerBatch is the maxItems limit of the membership add/remove payloads
+const maxMembersPerBatch = 50
+
+// AddTeamMembers adds members to a team. More than 50 members are sent in
+// several calls and the responses merged.
 func (c *AtlassianClient) AddTeamMembers(orgID, teamID string, members []TeamMember) (*PublicApiMembershipAddResponse, error) {
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams/%s/members/add", orgID, teamID)
+	if len(members) == 0 {
+		return nil, fmt.Errorf("members must contain at least 1 item")
+	}
+
+	merged := &PublicApiMembershipAddResponse{Errors: []PublicApiMembershipCodedError{}, Members: []TeamMember{}}
+	processed := 0
+	for batch := range slices.Chunk(members, maxMembersPerBatch) {
+		addResponse, err := c.addTeamMembersBatch(orgID, teamID, batch)
+		if err != nil {
+			return nil, batchError(err, processed, len(members))
+		}
+		merged.Errors = append(merged.Errors, addResponse.Errors...)
+		merged.Members = append(merged.Members, addResponse.Members...)
+		processed += len(batch)
+	}
+
+	return merged, nil
+}
+
+func (c *AtlassianClient) addTeamMembersBatch(orgID, teamID string, members []TeamMember) (*PublicApiMembershipAddResponse, error) {
+	path := c.teamsOrgPath(orgID, "/teams/"+teamID+"/members/add")
 
 	request := PublicApiMembershipAddPayload{
 		Members: members,
 	}
 
-	resp, err := c.makeRequestWithHeaders("POST", path, request, map[string]string{
-		"Accept": "*/*",
-	})
+	resp, err := c.makeRequestAccepting("POST", path, request, acceptAny)
 	if err != nil {
 		return nil, fmt.Errorf("error adding team members: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error adding team members: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("adding team members", resp)
 	}
 
 	var addResponse PublicApiMembershipAddResponse
-	if err := json.NewDecoder(resp.Body).Decode(&addResponse); err != nil {
+	if err := decodeResponse(resp, &addResponse); err != nil {
 		return nil, fmt.Errorf("error decoding add members response: %w", err)
 	}
 
 	return &addResponse, nil
 }
 
-// RemoveTeamMembers removes members from a team
+// RemoveTeamMembers removes members from a team. More than 50 members are
+// sent in several calls and the responses merged.
 func (c *AtlassianClient) RemoveTeamMembers(orgID, teamID string, members []TeamMember) (*PublicApiMembershipRemoveResponse, error) {
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams/%s/members/remove", orgID, teamID)
+	if len(members) == 0 {
+		return nil, fmt.Errorf("members must contain at least 1 item")
+	}
+
+	merged := &PublicApiMembershipRemoveResponse{Errors: []PublicApiMembershipCodedError{}}
+	processed := 0
+	for batch := range slices.Chunk(members, maxMembersPerBatch) {
+		removeResponse, err := c.removeTeamMembersBatch(orgID, teamID, batch)
+		if err != nil {
+			return nil, batchError(err, processed, len(members))
+		}
+		merged.Errors = append(merged.Errors, removeResponse.Errors...)
+		processed += len(batch)
+	}
+
+	return merged, nil
+}
+
+func (c *AtlassianClient) removeTeamMembersBatch(orgID, teamID string, members []TeamMember) (*PublicApiMembershipRemoveResponse, error) {
+	path := c.teamsOrgPath(orgID, "/teams/"+teamID+"/members/remove")
 
 	request := PublicApiMembershipRemovePayload{
 		Members: members,
 	}
 
-	resp, err := c.makeRequestWithHeaders("POST", path, request, map[string]string{
-		"Accept": "*/*",
-	})
+	resp, err := c.makeRequestAccepting("POST", path, request, acceptAny)
 	if err != nil {
 		return nil, fmt.Errorf("error removing team members: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error removing team members: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("removing team members", resp)
 	}
 
 	var removeResponse PublicApiMembershipRemoveResponse
-	if err := json.NewDecoder(resp.Body).Decode(&removeResponse); err != nil {
+	if err := decodeResponse(resp, &removeResponse); err != nil {
 		return nil, fmt.Errorf("error decoding remove members response: %w", err)
 	}
 
 	return &removeResponse, nil
 }
+
+// batchError notes how many members were already processed by earlier
+// batches when a later batch fails, since those changes are not rolled back
+func batchError(err error, processed, total int) error {
+	if processed == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (%d of %d members were processed before the failure)", err, processed, total)
+}
+
+// MemberIterator pages through the members of a team with FetchTeamMembers
+type MemberIterator struct {
+	client *AtlassianClient
+	orgID  string
+	teamID string
+	siteId string
+	after  string
+	done   bool
+}
+
+// NewMemberIterator returns an iterator over the members of a team
+func (c *AtlassianClient) NewMemberIterator(orgID, teamID, siteId string) *MemberIterator {
+	return &MemberIterator{client: c, orgID: orgID, teamID: teamID, siteId: siteId}
+}
+
+// Next fetches the next page of up to 50 members. The boolean reports whether
+// more pages remain; once it is false, further calls return no members.
+func (it *MemberIterator) Next(ctx context.Context) ([]TeamMember, bool, error) {
+	if it.done {
+		return nil, false, nil
+	}
+
+	page, err := it.client.WithContext(ctx).FetchTeamMembers(it.orgID, it.teamID, it.siteId, it.after, maxMembersPerPage)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Stop on a missing or repeated cursor so a misbehaving API cannot loop forever
+	if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" || page.PageInfo.EndCursor == it.after {
+		it.done = true
+	}
+	it.after = page.PageInfo.EndCursor
+
+	return page.Results, !it.done, nil
+}
+
+// fetchAllTeamMembers returns every member of a team
+func (c *AtlassianClient) fetchAllTeamMembers(orgID, teamID, siteId string) ([]TeamMember, error) {
+	members := []TeamMember{}
+	it := c.NewMemberIterator(orgID, teamID, siteId)
+
+	for {
+		page, more, err := it.Next(c.context())
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if !more {
+			return members, nil
+		}
+	}
+}
+
+// GetTeamMember reports whether accountID is a member of a team. The API has
+// no lookup by account, so members are paged until the account is found.
+func (c *AtlassianClient) GetTeamMember(orgID, teamID, accountID, siteId string) (*TeamMember, bool, error) {
+	it := c.NewMemberIterator(orgID, teamID, siteId)
+
+	for {
+		page, more, err := it.Next(c.context())
+		if err != nil {
+			return nil, false, err
+		}
+		for _, member := range page {
+			if member.AccountID == accountID {
+				return &member, true, nil
+			}
+		}
+		if !more {
+			return nil, false, nil
+		}
+	}
+}
+
+// ErrTeamMembersNotVisible is returned by WaitForTeamMembers when added
+// members are still missing from the member list after the timeout
+var ErrTeamMembersNotVisible = errors.New("team members not yet visible")
+
+// WaitForTeamMembers fetches the members of a team until every expected
+// account is listed, because the API may briefly omit members that were just
+// added. Attempts back off exponentially from RetryWaitMin up to RetryWaitMax
+// and stop after timeout or when the retry budget runs out, returning the last
+// fetched members.
+func (c *AtlassianClient) WaitForTeamMembers(orgID, teamID, siteId string, expected []TeamMember, timeout time.Duration) ([]TeamMember, error) {
+	deadline := time.Now().Add(timeout)
+
+	for attempt := 0; ; attempt++ {
+		members, err := c.fetchAllTeamMembers(orgID, teamID, siteId)
+		if err != nil {
+			return nil, err
+		}
+
+		listed := make(map[string]bool, len(members))
+		for _, member := range members {
+			listed[member.AccountID] = true
+		}
+		missing := 0
+		for _, member := range expected {
+			if !listed[member.AccountID] {
+				missing++
+			}
+		}
+		if missing == 0 {
+			return members, nil
+		}
+
+		wait := c.RetryWaitMin << attempt
+		if wait <= 0 || wait > c.RetryWaitMax {
+			wait = c.RetryWaitMax
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return members, fmt.Errorf("%w: %d of %d members missing after %s", ErrTeamMembersNotVisible, missing, len(expected), timeout)
+		}
+		if !c.retryBudget.take(wait) {
+			return members, fmt.Errorf("%w: %d of %d members missing when the retry budget ran out", ErrTeamMembersNotVisible, missing, len(expected))
+		}
+
+		tflog.Debug(c.context(), "Waiting for added team members to become visible", map[string]any{
+			"team_id": teamID,
+			"missing": missing,
+			"wait":    wait.String(),
+		})
+		if err := c.sleep(c.context(), wait); err != nil {
+			return members, err
+		}
+	}
+}
+
+// CountTeamMembers returns the number of members of a team. The API reports
+// no total in its pagination metadata and has no count endpoint, so this
+// pages through the members 50 at a time.
+func (c *AtlassianClient) CountTeamMembers(orgID, teamID, siteId string) (int, error) {
+	members, err := c.fetchAllTeamMembers(orgID, teamID, siteId)
+	if err != nil {
+		return 0, err
+	}
+	return len(members), nil
+}
+
+// GetTeamWithMembers retrieves a team together with all of its members. The
+// public Teams API has no endpoint returning members inline with the team, so
+// this combines GetTeamInSite with paged member fetches. Both run concurrently
+// and the first failure cancels the other. Like the team, members not found
+// in the site are fetched again without the site scope, and they are always
+// read in the scope the team was found in.
+func (c *AtlassianClient) GetTeamWithMembers(teamID, siteId string) (*TeamResponseWithMembers, error) {
+	siteId = c.siteIdOrDefault(siteId)
+	orgID := c.getOrgIdentifier()
+	g, ctx := errgroup.WithContext(c.context())
+	client := c.WithContext(ctx)
+
+	var team *TeamResponse
+	var members []TeamMember
+	var teamSiteId, membersSiteId string
+	g.Go(func() error {
+		var err error
+		team, teamSiteId, err = client.getTeamInSite(teamID, siteId)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		membersSiteId = siteId
+		members, err = client.fetchAllTeamMembers(orgID, teamID, siteId)
+		if client.fallBackWithoutSite(siteId, err) {
+			membersSiteId = ""
+			members, err = client.withoutSiteId().fetchAllTeamMembers(orgID, teamID, "")
+		}
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// A team only found without the site scope has its members read without it too
+	if teamSiteId == "" && membersSiteId != "" {
+		var err error
+		if members, err = c.withoutSiteId().fetchAllTeamMembers(orgID, teamID, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return &TeamResponseWithMembers{
+		TeamID:          team.TeamID,
+		DisplayName:     team.DisplayName,
+		Description:     team.Description,
+		TeamType:        team.TeamType,
+		OrganizationId:  team.OrganizationId,
+		CreatorId:       team.CreatorId,
+		State:           team.State,
+		Members:         members,
+		UserPermissions: team.UserPermissions,
+		RawJSON:         team.RawJSON,
+	}, nil
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// maxTeamsPerBulkOperation is the most team IDs the bulk archive and
+// unarchive endpoints accept in one request
+const maxTeamsPerBulkOperation = 100
+
+// maxTeamsPerPage is the largest page size the teams list endpoint accepts
+const maxTeamsPerPage = 300
+
 // PublicApiBulkOperationRequest matches OpenAPI spec
 type PublicApiBulkOperationRequest struct {
 	TeamIDs []string `json:"teamIds"` // maxItems: 100, minItems: 1
@@ -33,13 +42,14 @@ type PublicApiTeamPaginationResult struct {
 	Entities []Team `json:"entities"`         // The list of teams
 }
 
-// ArchiveTeams archives multiple teams in bulk
+// ArchiveTeams archives multiple teams in bulk. Duplicate team IDs are sent once.
 func (c *AtlassianClient) ArchiveTeams(orgID string, teamIDs []string) (*PublicApiBulkOperationResponse, error) {
-	if len(teamIDs) == 0 || len(teamIDs) > 100 {
-		return nil, fmt.Errorf("teamIDs must contain between 1 and 100 items, got %d", len(teamIDs))
+	teamIDs = uniqueTeamIDs(teamIDs)
+	if len(teamIDs) == 0 || len(teamIDs) > maxTeamsPerBulkOperation {
+		return nil, fmt.Errorf("teamIDs must contain between 1 and %d unique items, got %d", maxTeamsPerBulkOperation, len(teamIDs))
 	}
 
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams/archive", orgID)
+	path := c.teamsOrgPath(orgID, "/teams/archive")
 
 	request := PublicApiBulkOperationRequest{
 		TeamIDs: teamIDs,
@@ -52,25 +62,25 @@ func (c *AtlassianClient) ArchiveTeams(orgID string, teamIDs []string) (*PublicA
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error archiving teams: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("archiving teams", resp)
 	}
 
 	var archiveResponse PublicApiBulkOperationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&archiveResponse); err != nil {
+	if err := decodeResponse(resp, &archiveResponse); err != nil {
 		return nil, fmt.Errorf("error decoding archive response: %w", err)
 	}
 
 	return &archiveResponse, nil
 }
 
-// UnarchiveTeams unarchives multiple teams in bulk
+// UnarchiveTeams unarchives multiple teams in bulk. Duplicate team IDs are sent once.
 func (c *AtlassianClient) UnarchiveTeams(orgID string, teamIDs []string) (*PublicApiBulkOperationResponse, error) {
-	if len(teamIDs) == 0 || len(teamIDs) > 100 {
-		return nil, fmt.Errorf("teamIDs must contain between 1 and 100 items, got %d", len(teamIDs))
+	teamIDs = uniqueTeamIDs(teamIDs)
+	if len(teamIDs) == 0 || len(teamIDs) > maxTeamsPerBulkOperation {
+		return nil, fmt.Errorf("teamIDs must contain between 1 and %d unique items, got %d", maxTeamsPerBulkOperation, len(teamIDs))
 	}
 
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams/unarchive", orgID)
+	path := c.teamsOrgPath(orgID, "/teams/unarchive")
 
 	request := PublicApiBulkOperationRequest{
 		TeamIDs: teamIDs,
@@ -83,21 +93,185 @@ func (c *AtlassianClient) UnarchiveTeams(orgID string, teamIDs []string) (*Publi
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error unarchiving teams: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("unarchiving teams", resp)
 	}
 
 	var unarchiveResponse PublicApiBulkOperationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&unarchiveResponse); err != nil {
+	if err := decodeResponse(resp, &unarchiveResponse); err != nil {
 		return nil, fmt.Errorf("error decoding unarchive response: %w", err)
 	}
 
 	return &unarchiveResponse, nil
 }
 
+// RecreateTeamWithType creates a team of type newType to replace old, since
+// the Teams API cannot change the type of a team. The new team gets the
+// display name and description of old and its members: members, or the
+// current members of old if nil. Members are read and the team is created in
+// the client's site. Members are not carried over to EXTERNAL and
+// ORG_ADMIN_MANAGED teams, whose membership cannot be managed through the API.
+// If adding the members fails, the new team is deleted again. old is left as
+// it is, callers delete or archive it once the new team is in place.
+func (c *AtlassianClient) RecreateTeamWithType(old Team, newType string, members []TeamMember) (*TeamResponseWithMembers, error) {
+	orgID := c.getOrgIdentifier()
+	siteId := c.SiteId
+
+	if members == nil {
+		var err error
+		members, err = c.fetchAllTeamMembers(orgID, old.TeamID, siteId)
+		if err != nil {
+			return nil, fmt.Errorf("error capturing members of team %s: %w", old.TeamID, err)
+		}
+	}
+
+	team, err := c.CreateTeam(&CreateTeamRequest{
+		DisplayName: old.DisplayName,
+		Description: old.Description,
+		TeamType:    newType,
+		SiteId:      siteId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The creator may already be a member of the new team
+	missing, _ := diffTeamMembers(team.Members, members)
+	if newType != "EXTERNAL" && newType != "ORG_ADMIN_MANAGED" && len(missing) > 0 {
+		added, err := c.AddTeamMembers(orgID, team.TeamID, missing)
+		if err == nil && len(added.Errors) > 0 {
+			err = fmt.Errorf("%d members could not be added, first: %s: %s - %s",
+				len(added.Errors), added.Errors[0].AccountID, added.Errors[0].Code, added.Errors[0].Message)
+		}
+		if err != nil {
+			if deleteErr := c.deleteTeamInOrg(orgID, team.TeamID); deleteErr != nil {
+				return nil, fmt.Errorf("error adding members to new team %s, which could not be deleted again (%s): %w", team.TeamID, deleteErr, err)
+			}
+			return nil, fmt.Errorf("error adding members to new team: %w", err)
+		}
+		team.Members = append(team.Members, added.Members...)
+	}
+
+	return team, nil
+}
+
+// uniqueTeamIDs returns teamIDs without duplicates, in their original order
+func uniqueTeamIDs(teamIDs []string) []string {
+	seen := make(map[string]bool, len(teamIDs))
+	unique := make([]string, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		if !seen[teamID] {
+			seen[teamID] = true
+			unique = append(unique, teamID)
+		}
+	}
+	return unique
+}
+
+// DeleteTeams deletes multiple teams. The Teams API has no bulk delete
+// endpoint, so teams are deleted one at a time; failures are collected in the
+// response and summarized in the returned error rather than aborting the batch.
+func (c *AtlassianClient) DeleteTeams(orgID string, teamIDs []string) (*PublicApiBulkOperationResponse, error) {
+	deleteResponse := &PublicApiBulkOperationResponse{
+		Errors:            []PublicApiBulkTeamOperationError{},
+		SuccessfulTeamIds: []string{},
+	}
+
+	for _, teamID := range teamIDs {
+		err := c.deleteTeamInOrg(orgID, teamID)
+		if err == nil {
+			deleteResponse.SuccessfulTeamIds = append(deleteResponse.SuccessfulTeamIds, teamID)
+			continue
+		}
+
+		deleteResponse.Errors = append(deleteResponse.Errors, bulkTeamError(teamID, err))
+	}
+
+	return deleteResponse, deleteResponse.Err("deleting")
+}
+
+// UpdateTeamsDescription sets the description of multiple teams. The Teams
+// API has no bulk update endpoint, so every team is patched with UpdateTeam;
+// failures are collected in the response like for DeleteTeams.
+func (c *AtlassianClient) UpdateTeamsDescription(orgID string, teamIDs []string, description string) (*PublicApiBulkOperationResponse, error) {
+	client := c.WithOrgId(orgID)
+	updateResponse := &PublicApiBulkOperationResponse{
+		Errors:            []PublicApiBulkTeamOperationError{},
+		SuccessfulTeamIds: []string{},
+	}
+
+	for _, teamID := range teamIDs {
+		_, err := client.UpdateTeam(teamID, &UpdateTeamRequest{Description: &description})
+		if err == nil {
+			updateResponse.SuccessfulTeamIds = append(updateResponse.SuccessfulTeamIds, teamID)
+			continue
+		}
+
+		updateResponse.Errors = append(updateResponse.Errors, bulkTeamError(teamID, err))
+	}
+
+	return updateResponse, updateResponse.Err("updating the description of")
+}
+
+// bulkTeamError converts the error of a single team operation into the
+// per-team error of a bulk response
+func bulkTeamError(teamID string, err error) PublicApiBulkTeamOperationError {
+	bulkErr := PublicApiBulkTeamOperationError{TeamID: teamID, Message: err.Error()}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		bulkErr.Code = apiErr.Code
+		if apiErr.Message != "" {
+			bulkErr.Message = apiErr.Message
+		}
+	}
+	return bulkErr
+}
+
+// BulkOperationError reports the teams a bulk operation failed for. The
+// operation is not atomic, so SuccessfulTeamIds lists the teams it did apply to.
+type BulkOperationError struct {
+	Operation         string
+	Failed            []PublicApiBulkTeamOperationError
+	SuccessfulTeamIds []string
+}
+
+func (e *BulkOperationError) Error() string {
+	details := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		if f.Code != "" {
+			details[i] = fmt.Sprintf("%s: %s - %s", f.TeamID, f.Code, f.Message)
+		} else {
+			details[i] = fmt.Sprintf("%s: %s", f.TeamID, f.Message)
+		}
+	}
+	total := len(e.Failed) + len(e.SuccessfulTeamIds)
+	return fmt.Sprintf("error %s %d of %d teams: %s", e.Operation, len(e.Failed), total, strings.Join(details, "; "))
+}
+
+// FailedTeamIDs returns the IDs of the teams the operation failed for
+func (e *BulkOperationError) FailedTeamIDs() []string {
+	teamIDs := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		teamIDs[i] = f.TeamID
+	}
+	return teamIDs
+}
+
+// Err returns a *BulkOperationError if the response reports errors for any
+// team, nil otherwise. operation describes the request, e.g. "archiving".
+func (r *PublicApiBulkOperationResponse) Err(operation string) error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return &BulkOperationError{
+		Operation:         operation,
+		Failed:            r.Errors,
+		SuccessfulTeamIds: r.SuccessfulTeamIds,
+	}
+}
+
 // RestoreTeam restores a single soft-deleted team
 func (c *AtlassianClient) RestoreTeam(orgID, teamID string) error {
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams/%s/restore", orgID, teamID)
+	path := c.teamsOrgPath(orgID, "/teams/"+teamID+"/restore")
 
 	resp, err := c.makeRequest("POST", path, nil)
 	if err != nil {
@@ -105,43 +279,44 @@ func (c *AtlassianClient) RestoreTeam(orgID, teamID string) error {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("team not found for restore: %s", teamID)
-	}
-
 	if resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error restoring team: %s - %s", resp.Status, string(body))
+		return newAPIError("restoring team", resp)
 	}
 
 	return nil
 }
 
-// GetTeams retrieves a list of teams for an organization with optional parameters
-func (c *AtlassianClient) GetTeams(orgID, siteId string, size int32, cursor string) (*PublicApiTeamPaginationResult, error) {
-	path := fmt.Sprintf("/public/teams/v1/org/%s/teams", orgID)
-
-	// Build query parameters according to OpenAPI spec
-	queryParams := make([]string, 0)
+// GetTeams retrieves a page of teams for an organization, scoped to siteId or
+// the client's SiteId if it is empty. A size of 0 requests
+// the API maximum of 300 teams, larger sizes are clamped to it. extraParams
+// are added to the query for filters of newer API versions; they cannot
+// override siteId, size or cursor. The query is encoded sorted by key.
+func (c *AtlassianClient) GetTeams(orgID, siteId string, size int32, cursor string, extraParams map[string]string) (*PublicApiTeamPaginationResult, error) {
+	path := c.teamsOrgPath(orgID, "/teams")
+
+	// Build query parameters according to OpenAPI spec, the built-in ones replace colliding extras
+	query := url.Values{}
+	for key, value := range extraParams {
+		query.Set(key, value)
+	}
+	query.Del("siteId")
+	query.Del("cursor")
 
-	if siteId != "" {
-		queryParams = append(queryParams, "siteId="+siteId)
+	if siteId = c.siteIdOrDefault(siteId); siteId != "" {
+		query.Set("siteId", siteId)
 	}
 
-	if size > 0 {
-		if size > 300 {
-			size = 300 // Maximum allowed by API
-		}
-		queryParams = append(queryParams, "size="+strconv.FormatInt(int64(size), 10))
+	// Default to the largest page so paging through all teams takes the fewest requests
+	if size <= 0 || size > maxTeamsPerPage {
+		size = maxTeamsPerPage
 	}
+	query.Set("size", strconv.FormatInt(int64(size), 10))
 
 	if cursor != "" {
-		queryParams = append(queryParams, "cursor="+cursor)
+		query.Set("cursor", cursor)
 	}
 
-	if len(queryParams) > 0 {
-		path += "?" + strings.Join(queryParams, "&")
-	}
+	path += "?" + query.Encode()
 
 	resp, err := c.makeRequest("GET", path, nil)
 	if err != nil {
@@ -150,14 +325,111 @@ func (c *AtlassianClient) GetTeams(orgID, siteId string, size int32, cursor stri
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error getting teams list: %s - %s", resp.Status, string(body))
+		return nil, newAPIError("getting teams list", resp)
 	}
 
 	var teamsResponse PublicApiTeamPaginationResult
-	if err := json.NewDecoder(resp.Body).Decode(&teamsResponse); err != nil {
+	if err := decodeResponse(resp, &teamsResponse); err != nil {
 		return nil, fmt.Errorf("error decoding teams list response: %w", err)
 	}
 
 	return &teamsResponse, nil
 }
+
+// TeamsPageError is returned by GetAllTeams when a page still fails after the
+// client's retries. Pass Cursor to GetAllTeams to resume from the failed page.
+type TeamsPageError struct {
+	Cursor string
+	Err    error
+}
+
+func (e *TeamsPageError) Error() string {
+	return fmt.Sprintf("error getting teams page at cursor %q: %s", e.Cursor, e.Err)
+}
+
+func (e *TeamsPageError) Unwrap() error {
+	return e.Err
+}
+
+// GetAllTeams pages through the organization's teams starting at cursor, ""
+// for the first page. Each page goes through the client's retry and backoff.
+// If a page fails, the teams fetched so far are returned together with a
+// *TeamsPageError holding the cursor to resume from.
+func (c *AtlassianClient) GetAllTeams(orgID, siteId, cursor string) ([]Team, error) {
+	teams := []Team{}
+
+	for page := 1; ; page++ {
+		result, err := c.GetTeams(orgID, siteId, maxTeamsPerPage, cursor, nil)
+		if err != nil {
+			return teams, &TeamsPageError{Cursor: cursor, Err: err}
+		}
+		teams = append(teams, result.Entities...)
+
+		tflog.Debug(c.context(), "Fetched teams page", map[string]any{"page": page, "teams": len(teams)})
+
+		if result.Cursor == "" || result.Cursor == cursor {
+			return teams, nil
+		}
+		cursor = result.Cursor
+	}
+}
+
+// GetArchivedTeams returns the organization's archived teams. The list
+// endpoint has no state filter, so all teams are fetched and filtered.
+func (c *AtlassianClient) GetArchivedTeams(orgID, siteId string) ([]Team, error) {
+	teams, err := c.GetAllTeams(orgID, siteId, "")
+	if err != nil {
+		return nil, fmt.Errorf("error listing archived teams: %w", err)
+	}
+
+	archived := []Team{}
+	for _, team := range teams {
+		if team.State == "ARCHIVED" {
+			archived = append(archived, team)
+		}
+	}
+	return archived, nil
+}
+
+// ErrTeamNameNotFound is returned by GetTeamByName when no team has the given name
+var ErrTeamNameNotFound = errors.New("no team found with name")
+
+// GetTeamByName pages through the organization's teams and returns the single
+// team whose display name matches exactly
+func (c *AtlassianClient) GetTeamByName(orgID, siteId, name string) (*Team, error) {
+	return c.getTeamByName(orgID, siteId, name, false)
+}
+
+// GetTeamByNameIgnoreCase is like GetTeamByName but matches display names
+// case-insensitively
+func (c *AtlassianClient) GetTeamByNameIgnoreCase(orgID, siteId, name string) (*Team, error) {
+	return c.getTeamByName(orgID, siteId, name, true)
+}
+
+func (c *AtlassianClient) getTeamByName(orgID, siteId, name string, ignoreCase bool) (*Team, error) {
+	teams, err := c.GetAllTeams(orgID, siteId, "")
+	if err != nil {
+		return nil, fmt.Errorf("error looking up team by name: %w", err)
+	}
+
+	var matches []Team
+	for _, team := range teams {
+		if team.DisplayName == name || (ignoreCase && strings.EqualFold(team.DisplayName, name)) {
+			matches = append(matches, team)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTeamNameNotFound, name)
+	}
+
+	if len(matches) > 1 {
+		teamIDs := make([]string, len(matches))
+		for i, team := range matches {
+			teamIDs[i] = team.TeamID
+		}
+		return nil, fmt.Errorf("found %d teams with name %s: %s", len(matches), name, strings.Join(teamIDs, ", "))
+	}
+
+	return &matches[0], nil
+}
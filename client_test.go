@@ -0,0 +1,1192 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetTeamAPIPath(t *testing.T) {
+	client := &AtlassianClient{OrgId: "12345678-1234-1234-1234-123456789012"}
+
+	got := client.getTeamAPIPath("/teams/abc")
+	want := "/public/teams/v1/org/12345678-1234-1234-1234-123456789012/teams/abc"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestGetTeamAPIPathFallsBackToOrganization(t *testing.T) {
+	client := &AtlassianClient{Organization: "my-company"}
+
+	got := client.getTeamAPIPath("/teams/")
+	want := "/public/teams/v1/org/my-company/teams/"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestTeamsAPIVersionPaths(t *testing.T) {
+	for _, version := range []string{"", "v1", "v2"} {
+		client := &AtlassianClient{OrgId: "org", TeamsAPIVersion: version}
+		want := version
+		if want == "" {
+			want = "v1"
+		}
+
+		paths := map[string]string{
+			client.getTeamAPIPath("/teams/abc"):                  "/public/teams/" + want + "/org/org/teams/abc",
+			client.getTeamAPIPathWithQuery("/teams/abc", "site"): "/public/teams/" + want + "/org/org/teams/abc?siteId=site",
+			client.teamsOrgPath("other", "/teams/abc/members"):   "/public/teams/" + want + "/org/other/teams/abc/members",
+			client.teamsOrgPath("org", "/teams/archive"):         "/public/teams/" + want + "/org/org/teams/archive",
+		}
+		for got, want := range paths {
+			if got != want {
+				t.Errorf("version %q: expected %s, got %s", version, want, got)
+			}
+		}
+	}
+}
+
+func TestTeamsAPIVersionIsUsedByRequests(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"entities":[],"errors":[],"members":[],"results":[],"pageInfo":{}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.TeamsAPIVersion = "v2"
+	_, _ = client.GetTeams("org", "", 1, "", nil)
+	_, _ = client.AddTeamMembers("org", "team", []TeamMember{{AccountID: "a"}})
+	_, _ = client.RemoveTeamMembers("org", "team", []TeamMember{{AccountID: "a"}})
+	_, _ = client.fetchAllTeamMembers("org", "team", "")
+	_, _ = client.ArchiveTeams("org", []string{"team"})
+	_ = client.DeleteTeam("team")
+
+	if len(paths) != 6 {
+		t.Fatalf("Expected 6 requests, got %v", paths)
+	}
+	for _, path := range paths {
+		if !strings.HasPrefix(path, "/public/teams/v2/org/org/teams") {
+			t.Errorf("Expected a v2 path, got %s", path)
+		}
+	}
+}
+
+func TestClientSiteIdScopesReads(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("siteId"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"entities":[],"results":[],"pageInfo":{}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "site-1", "org", server.URL)
+	_, _ = client.GetTeams("org", "", 1, "", nil)
+	_, _ = client.FetchTeamMembers("org", "team", "", "", 10)
+	_, _ = client.GetTeamInSite("team", "")
+	_, _ = client.GetTeams("org", "site-2", 1, "", nil)
+
+	if want := []string{"site-1", "site-1", "site-1", "site-2"}; !slices.Equal(queries, want) {
+		t.Errorf("Expected siteId queries %v, got %v", want, queries)
+	}
+}
+
+func TestGetTeamsExtraQueryParams(t *testing.T) {
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"entities":[],"pageInfo":{}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	_, err := client.GetTeams("org", "site-1", 10, "c1", map[string]string{
+		"query":  "platform & ops",
+		"expand": "members",
+		"size":   "1",
+	})
+	if err != nil {
+		t.Fatalf("GetTeams failed: %v", err)
+	}
+
+	if want := "cursor=c1&expand=members&query=platform+%26+ops&siteId=site-1&size=10"; rawQuery != want {
+		t.Errorf("Expected query %s, got %s", want, rawQuery)
+	}
+
+	// Cursors are opaque and may hold reserved characters, a colliding extra cannot replace them
+	_, err = client.GetTeams("org", "", 10, "a+b/c=d&e", map[string]string{"cursor": "injected"})
+	if err != nil {
+		t.Fatalf("GetTeams failed: %v", err)
+	}
+	if want := "cursor=a%2Bb%2Fc%3Dd%26e&size=10"; rawQuery != want {
+		t.Errorf("Expected query %s, got %s", want, rawQuery)
+	}
+
+	// Without a cursor of its own, a colliding extra is dropped rather than sent
+	_, err = client.GetTeams("org", "", 10, "", map[string]string{"cursor": "injected", "siteId": "site-2"})
+	if err != nil {
+		t.Fatalf("GetTeams failed: %v", err)
+	}
+	if want := "size=10"; rawQuery != want {
+		t.Errorf("Expected query %s, got %s", want, rawQuery)
+	}
+}
+
+func TestTeamAPIPathPrefersOrgId(t *testing.T) {
+	client, _ := NewAtlassianClient("token", "", "mycompany", "", "org-1", "https://api.atlassian.com")
+	if got, want := client.getTeamAPIPath("/teams"), "/public/teams/v1/org/org-1/teams"; got != want {
+		t.Errorf("Expected org_id in path %s, got %s", want, got)
+	}
+
+	// The legacy organization is only used without an org_id
+	client.OrgId = ""
+	if got, want := client.getTeamAPIPath("/teams"), "/public/teams/v1/org/mycompany/teams"; got != want {
+		t.Errorf("Expected organization in path %s, got %s", want, got)
+	}
+}
+
+func TestRequestURL(t *testing.T) {
+	for _, baseURL := range []string{"https://api.atlassian.com", "https://api.atlassian.com/", "https://api.atlassian.com//"} {
+		client, _ := NewAtlassianClient("token", "", "", "", "org", baseURL)
+		want := "https://api.atlassian.com/public/teams/v1/org/org/teams/abc"
+		if got := client.requestURL(client.getTeamAPIPath("/teams/abc")); got != want {
+			t.Errorf("base URL %q: expected %s, got %s", baseURL, want, got)
+		}
+	}
+
+	client := &AtlassianClient{BaseURL: "https://gateway.example.com/atlassian/"}
+	if got, want := client.requestURL("/users/a/manage/profile"), "https://gateway.example.com/atlassian/users/a/manage/profile"; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestGetTeamByName(t *testing.T) {
+	pages := map[string]PublicApiTeamPaginationResult{
+		"": {
+			Cursor:   "page2",
+			Entities: []Team{{TeamID: "t1", DisplayName: "Platform"}, {TeamID: "t2", DisplayName: "Design"}},
+		},
+		"page2": {
+			Entities: []Team{{TeamID: "t3", DisplayName: "design"}, {TeamID: "t4", DisplayName: "Infra"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("cursor")])
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	team, err := client.GetTeamByName("org", "", "Infra")
+	if err != nil {
+		t.Fatalf("Expected team on second page to be found, got: %v", err)
+	}
+	if team.TeamID != "t4" {
+		t.Errorf("Expected t4, got %s", team.TeamID)
+	}
+
+	if _, err := client.GetTeamByName("org", "", "Missing"); err == nil {
+		t.Error("Expected an error when no team matches")
+	}
+
+	team, err = client.GetTeamByName("org", "", "Design")
+	if err != nil || team.TeamID != "t2" {
+		t.Errorf("Expected exact match t2, got %v, %v", team, err)
+	}
+
+	if _, err := client.GetTeamByNameIgnoreCase("org", "", "Design"); err == nil {
+		t.Error("Expected an error when multiple teams match case-insensitively")
+	}
+}
+
+func TestGetTeamReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Arequestid", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"TEAM_NOT_FOUND","message":"Team does not exist"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	_, err := client.GetTeam("missing")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Code != "TEAM_NOT_FOUND" || apiErr.Message != "Team does not exist" {
+		t.Errorf("Expected decoded error body, got code=%q message=%q", apiErr.Code, apiErr.Message)
+	}
+	if !isNotFoundError(err) {
+		t.Error("Expected isNotFoundError to report true")
+	}
+	if apiErr.RequestID != "req-123" || !strings.Contains(err.Error(), "req-123") {
+		t.Errorf("Expected request ID in error, got: %v", err)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "5")
+	header.Set("Retry-After", "30")
+
+	rateLimit := parseRateLimit(header)
+	if rateLimit.Limit != 100 || rateLimit.Remaining != 5 || rateLimit.RetryAfter != "30" {
+		t.Errorf("Unexpected rate limit: %+v", rateLimit)
+	}
+	if !rateLimit.isLow() {
+		t.Error("Expected 5 of 100 remaining to be reported as low")
+	}
+
+	rateLimit = parseRateLimit(http.Header{})
+	if rateLimit.Limit != -1 || rateLimit.Remaining != -1 || rateLimit.isLow() {
+		t.Errorf("Expected missing headers to be reported as absent, got: %+v", rateLimit)
+	}
+}
+
+func TestTeamMembersAgainstFakeServer(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	members := []TeamMember{{AccountID: "a"}, {AccountID: "b"}}
+	if _, err := client.AddTeamMembers("org-1", team.TeamID, members); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+	if _, err := client.RemoveTeamMembers("org-1", team.TeamID, members[:1]); err != nil {
+		t.Fatalf("RemoveTeamMembers failed: %v", err)
+	}
+
+	fetched, err := client.FetchTeamMembers("org-1", team.TeamID, "", "", 50)
+	if err != nil {
+		t.Fatalf("FetchTeamMembers failed: %v", err)
+	}
+	if len(fetched.Results) != 1 || fetched.Results[0].AccountID != "b" {
+		t.Errorf("Expected only member b, got %+v", fetched.Results)
+	}
+	if got := fake.teamMembers(team.TeamID); len(got) != 1 || got[0] != "b" {
+		t.Errorf("Expected server to hold only member b, got %v", got)
+	}
+}
+
+func TestTeamMembersAreBatched(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	members := make([]TeamMember, 120)
+	for i := range members {
+		members[i] = TeamMember{AccountID: fmt.Sprintf("account-%d", i)}
+	}
+
+	addResp, err := client.AddTeamMembers("org-1", team.TeamID, members)
+	if err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+	if len(addResp.Members) != 120 || len(fake.teamMembers(team.TeamID)) != 120 {
+		t.Errorf("Expected all 120 members to be added, got %d in response and %d on the server", len(addResp.Members), len(fake.teamMembers(team.TeamID)))
+	}
+
+	if _, err := client.RemoveTeamMembers("org-1", team.TeamID, members[:60]); err != nil {
+		t.Fatalf("RemoveTeamMembers failed: %v", err)
+	}
+	if got := len(fake.teamMembers(team.TeamID)); got != 60 {
+		t.Errorf("Expected 60 members left on the server, got %d", got)
+	}
+
+	if _, err := client.AddTeamMembers("org-1", team.TeamID, nil); err == nil {
+		t.Error("Expected an error when adding no members")
+	}
+}
+
+func TestFetchTeamMembersPagesBeyondTheAPIMaximum(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+	members := make([]TeamMember, 130)
+	for i := range members {
+		members[i] = TeamMember{AccountID: fmt.Sprintf("account-%d", i)}
+	}
+	if _, err := client.AddTeamMembers("org-1", team.TeamID, members); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	before := len(fake.requestsMatching("/members")) - len(fake.requestsMatching("/members/add"))
+	fetched, err := client.FetchTeamMembers("org-1", team.TeamID, "", "", 120)
+	if err != nil {
+		t.Fatalf("FetchTeamMembers failed: %v", err)
+	}
+	if len(fetched.Results) != 120 || fetched.Results[119].AccountID != "account-119" {
+		t.Errorf("Expected the first 120 members, got %d", len(fetched.Results))
+	}
+	if !fetched.PageInfo.HasNextPage {
+		t.Error("Expected the last page to report more members")
+	}
+	if got := len(fake.requestsMatching("/members")) - len(fake.requestsMatching("/members/add")) - before; got != 3 {
+		t.Errorf("Expected 120 members to be fetched in 3 pages, got %d", got)
+	}
+
+	rest, err := client.FetchTeamMembers("org-1", team.TeamID, "", fetched.PageInfo.EndCursor, 500)
+	if err != nil {
+		t.Fatalf("FetchTeamMembers failed: %v", err)
+	}
+	if len(rest.Results) != 10 || rest.PageInfo.HasNextPage {
+		t.Errorf("Expected the remaining 10 members on the last page, got %d", len(rest.Results))
+	}
+}
+
+func TestDeleteTeamsAggregatesErrors(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	// Deleting an unknown team is treated as success, like DeleteTeam
+	deleteResp, err := client.DeleteTeams("org-1", []string{team.TeamID, "unknown"})
+	if err != nil {
+		t.Fatalf("DeleteTeams failed: %v", err)
+	}
+	if len(deleteResp.SuccessfulTeamIds) != 2 || fake.team(team.TeamID) != nil {
+		t.Errorf("Expected both teams to be reported deleted, got %+v", deleteResp)
+	}
+
+	fake.Close()
+	deleteResp, err = client.DeleteTeams("org-1", []string{"a", "b"})
+	if err == nil || len(deleteResp.Errors) != 2 {
+		t.Errorf("Expected both deletions to fail against a closed server, got %+v, %v", deleteResp, err)
+	}
+}
+
+func TestBulkTeamOperationsDedupTeamIDs(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	result, err := client.ArchiveTeams("org-1", []string{team.TeamID, team.TeamID})
+	if err != nil {
+		t.Fatalf("ArchiveTeams failed: %v", err)
+	}
+	if !slices.Equal(result.SuccessfulTeamIds, []string{team.TeamID}) {
+		t.Errorf("Expected %s to be archived once, got %v", team.TeamID, result.SuccessfulTeamIds)
+	}
+
+	// 101 unique IDs exceed the limit even when duplicates are dropped
+	teamIDs := make([]string, 0, 2*maxTeamsPerBulkOperation+1)
+	for i := range maxTeamsPerBulkOperation + 1 {
+		teamID := fmt.Sprintf("team-%d", i)
+		teamIDs = append(teamIDs, teamID, teamID)
+	}
+	before := len(fake.requestsMatching("/teams/unarchive"))
+	if _, err := client.UnarchiveTeams("org-1", teamIDs); err == nil {
+		t.Error("Expected more than 100 unique team IDs to be rejected")
+	}
+	if len(fake.requestsMatching("/teams/unarchive")) != before {
+		t.Error("Expected the over-limit request not to be sent")
+	}
+
+	// 200 IDs with 100 unique fit in one request
+	if _, err := client.UnarchiveTeams("org-1", teamIDs[:2*maxTeamsPerBulkOperation]); err != nil {
+		t.Errorf("Expected 100 unique team IDs to be accepted, got %v", err)
+	}
+}
+
+func TestRecreateTeamWithType(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	old, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", Description: "Platform team", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+	if _, err := client.AddTeamMembers("org-1", old.TeamID, []TeamMember{{AccountID: "a"}, {AccountID: "b"}}); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	oldTeam := Team{TeamID: old.TeamID, DisplayName: old.DisplayName, Description: old.Description, TeamType: old.TeamType}
+	team, err := client.RecreateTeamWithType(oldTeam, "MEMBER_INVITE", nil)
+	if err != nil {
+		t.Fatalf("RecreateTeamWithType failed: %v", err)
+	}
+
+	if team.TeamID == old.TeamID || team.TeamType != "MEMBER_INVITE" || team.DisplayName != "Platform" || team.Description != "Platform team" {
+		t.Errorf("Expected a new MEMBER_INVITE team with the old name and description, got %+v", team)
+	}
+	if got := fake.teamMembers(team.TeamID); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected the members to be carried over, got %v", got)
+	}
+	if fake.team(old.TeamID) == nil {
+		t.Errorf("Expected the old team %s to be left to the caller", old.TeamID)
+	}
+
+	// Members cannot be carried over to an EXTERNAL team
+	oldTeam = Team{TeamID: team.TeamID, DisplayName: team.DisplayName, TeamType: team.TeamType}
+	external, err := client.RecreateTeamWithType(oldTeam, "EXTERNAL", nil)
+	if err != nil {
+		t.Fatalf("RecreateTeamWithType failed: %v", err)
+	}
+	if got := fake.teamMembers(external.TeamID); len(got) != 0 {
+		t.Errorf("Expected no members on the EXTERNAL team, got %v", got)
+	}
+}
+
+func TestBulkOperationResponseErr(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	result, err := client.ArchiveTeams("org-1", []string{team.TeamID, "unknown"})
+	if err != nil {
+		t.Fatalf("ArchiveTeams failed: %v", err)
+	}
+
+	var bulkErr *BulkOperationError
+	if !errors.As(result.Err("archiving"), &bulkErr) {
+		t.Fatalf("Expected a *BulkOperationError, got %v", result.Err("archiving"))
+	}
+	if !slices.Equal(bulkErr.FailedTeamIDs(), []string{"unknown"}) || !slices.Equal(bulkErr.SuccessfulTeamIds, []string{team.TeamID}) {
+		t.Errorf("Expected unknown to fail and %s to succeed, got %+v", team.TeamID, bulkErr)
+	}
+	if want := "error archiving 1 of 2 teams: unknown: NOT_FOUND - team not found"; bulkErr.Error() != want {
+		t.Errorf("Expected %q, got %q", want, bulkErr.Error())
+	}
+
+	result, err = client.UnarchiveTeams("org-1", []string{team.TeamID})
+	if err != nil || result.Err("unarchiving") != nil {
+		t.Errorf("Expected no error when every team succeeds, got %v, %v", err, result.Err("unarchiving"))
+	}
+}
+
+func TestUpdateTeamRequestDescription(t *testing.T) {
+	empty := ""
+	tests := map[string]struct {
+		request UpdateTeamRequest
+		want    string
+	}{
+		"unset":          {UpdateTeamRequest{DisplayName: "Platform"}, `{"displayName":"Platform"}`},
+		"explicit empty": {UpdateTeamRequest{DisplayName: "Platform", Description: &empty}, `{"displayName":"Platform","description":""}`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("Unable to marshal request: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(TeamResponse{TeamID: "t1"})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	if _, err := client.GetTeam("t1"); err == nil {
+		t.Fatal("Expected self-signed certificate to be rejected by default")
+	}
+
+	client.SetInsecureSkipVerify(true)
+	if _, err := client.GetTeam("t1"); err != nil {
+		t.Errorf("Expected request to succeed with verification disabled, got: %v", err)
+	}
+}
+
+func TestSetCACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(TeamResponse{TeamID: "t1"})
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatalf("Unable to write CA file: %v", err)
+	}
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	if err := client.SetCACertFile(caFile); err != nil {
+		t.Fatalf("SetCACertFile failed: %v", err)
+	}
+	if _, err := client.GetTeam("t1"); err != nil {
+		t.Errorf("Expected request to succeed with the custom CA, got: %v", err)
+	}
+
+	invalidFile := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("Unable to write invalid CA file: %v", err)
+	}
+	if err := client.SetCACertFile(invalidFile); err == nil {
+		t.Error("Expected an error for a file without certificates")
+	}
+}
+
+func TestGetTeamInSiteFallsBackToUnscoped(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("siteId") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(TeamResponse{TeamID: "t1"})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "site-1", "org", server.URL)
+
+	team, err := client.GetTeam("t1")
+	if err != nil {
+		t.Fatalf("Expected unscoped retry to find the team, got: %v", err)
+	}
+	if team.TeamID != "t1" || len(queries) != 2 || queries[0] != "siteId=site-1" || queries[1] != "" {
+		t.Errorf("Expected a scoped then an unscoped request, got %q", queries)
+	}
+}
+
+func TestGetTeamInSiteRequireSiteIdSkipsUnscopedFallback(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "site-1", "org", server.URL)
+	client.RequireSiteId = true
+
+	if _, err := client.GetTeam("t1"); !isNotFoundError(err) {
+		t.Fatalf("Expected the site-scoped 404, got: %v", err)
+	}
+	if len(queries) != 1 || queries[0] != "siteId=site-1" {
+		t.Errorf("Expected only the site-scoped request, got %q", queries)
+	}
+}
+
+func TestOAuthTokenRefreshOn401(t *testing.T) {
+	var refreshes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			var tokenReq oauthTokenRequest
+			_ = json.NewDecoder(r.Body).Decode(&tokenReq)
+			if tokenReq.GrantType != "refresh_token" || tokenReq.ClientID != "id" || tokenReq.ClientSecret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			refreshes++
+			_ = json.NewEncoder(w).Encode(oauthTokenResponse{
+				AccessToken:  fmt.Sprintf("access-%d", refreshes),
+				ExpiresIn:    3600,
+				RefreshToken: fmt.Sprintf("refresh-%d", refreshes),
+			})
+			return
+		}
+		// Only the second access token is accepted, as if the first had been revoked
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(TeamResponse{TeamID: "team-1"})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("", "", "", "", "org", server.URL)
+	client.OAuthTokenURL = server.URL + "/oauth/token"
+	if err := client.SetOAuthCredentials(context.Background(), "id", "secret", "refresh-0"); err != nil {
+		t.Fatalf("SetOAuthCredentials failed: %v", err)
+	}
+
+	team, err := client.GetTeam("team-1")
+	if err != nil {
+		t.Fatalf("GetTeam failed: %v", err)
+	}
+	if team.TeamID != "team-1" || refreshes != 2 {
+		t.Errorf("Expected the request to succeed after one refresh on 401, got team %+v after %d refreshes", team, refreshes)
+	}
+	if client.tokenSource.refreshToken != "refresh-2" {
+		t.Errorf("Expected the rotated refresh token to be kept, got %q", client.tokenSource.refreshToken)
+	}
+
+	if err := client.SetOAuthCredentials(context.Background(), "id", "wrong", "refresh-0"); err == nil {
+		t.Error("Expected invalid OAuth credentials to fail")
+	}
+}
+
+func TestPingClassifiesFailures(t *testing.T) {
+	tests := map[int]error{
+		http.StatusOK:                  nil,
+		http.StatusUnauthorized:        ErrAuthenticationFailed,
+		http.StatusForbidden:           ErrPermissionDenied,
+		http.StatusNotFound:            ErrOrgNotFound,
+		http.StatusInternalServerError: nil,
+	}
+
+	for status, want := range tests {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("size") != "1" {
+					t.Errorf("Expected Ping to request a single team, got query %q", r.URL.RawQuery)
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"entities":[]}`))
+			}))
+			defer server.Close()
+
+			client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+			err := client.Ping()
+			if status == http.StatusOK {
+				if err != nil {
+					t.Errorf("Expected Ping to succeed, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected Ping to fail")
+			}
+			if want != nil && !errors.Is(err, want) {
+				t.Errorf("Expected %v, got %v", want, err)
+			}
+			if want == nil && (errors.Is(err, ErrAuthenticationFailed) || errors.Is(err, ErrPermissionDenied) || errors.Is(err, ErrOrgNotFound)) {
+				t.Errorf("Expected an unclassified error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAcceptHeader(t *testing.T) {
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	_, _ = client.GetTeam("team-1")
+	_, _ = client.FetchTeamMembers("org", "team-1", "", "", 50)
+
+	client.DefaultAcceptHeader = "application/json"
+	_, _ = client.FetchTeamMembers("org", "team-1", "", "", 50)
+
+	want := []string{"application/json", "*/*", "application/json"}
+	if strings.Join(accepts, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected Accept headers %v, got %v", want, accepts)
+	}
+}
+
+func TestMemberIteratorPages(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload PublicApiMembershipFetchPayload
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		cursors = append(cursors, payload.After)
+
+		result := PublicApiFetchResponsePublicApiMembershipAccountId{
+			PageInfo: PublicApiPageInfoAccountId{EndCursor: "page2", HasNextPage: true},
+			Results:  []TeamMember{{AccountID: "a"}, {AccountID: "b"}},
+		}
+		if payload.After == "page2" {
+			result = PublicApiFetchResponsePublicApiMembershipAccountId{
+				PageInfo: PublicApiPageInfoAccountId{EndCursor: "page2-end"},
+				Results:  []TeamMember{{AccountID: "c"}},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	it := client.NewMemberIterator("org", "team-1", "")
+
+	page, more, err := it.Next(context.Background())
+	if err != nil || !more || len(page) != 2 {
+		t.Fatalf("Expected a first page of 2 members with more to come, got %v, %v, %v", page, more, err)
+	}
+	page, more, err = it.Next(context.Background())
+	if err != nil || more || len(page) != 1 || page[0].AccountID != "c" {
+		t.Fatalf("Expected a last page with member c, got %v, %v, %v", page, more, err)
+	}
+	page, more, err = it.Next(context.Background())
+	if err != nil || more || page != nil {
+		t.Errorf("Expected an exhausted iterator to return nothing, got %v, %v, %v", page, more, err)
+	}
+
+	if strings.Join(cursors, ",") != ",page2" {
+		t.Errorf("Expected cursors [\"\" page2], got %q", cursors)
+	}
+}
+
+func TestGetOrganization(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+
+	org, err := client.GetOrganization("org-1")
+	if err != nil {
+		t.Fatalf("GetOrganization failed: %v", err)
+	}
+	if org.ID != "org-1" || org.Name != "Fake Org org-1" || org.Type != "orgs" {
+		t.Errorf("Unexpected organization: %+v", org)
+	}
+}
+
+func TestGetTeamMemberStopsAtMatch(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+	members := make([]TeamMember, 120)
+	for i := range members {
+		members[i] = TeamMember{AccountID: fmt.Sprintf("account-%d", i)}
+	}
+	if _, err := client.AddTeamMembers("org-1", team.TeamID, members); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	fetches := len(fake.requestsMatching("/members"))
+	member, ok, err := client.GetTeamMember("org-1", team.TeamID, "account-10", "")
+	if err != nil || !ok || member.AccountID != "account-10" {
+		t.Fatalf("Expected account-10 to be found, got %v, %v, %v", member, ok, err)
+	}
+	if got := len(fake.requestsMatching("/members")) - fetches; got != 1 {
+		t.Errorf("Expected the lookup to stop after the first page, got %d fetches", got)
+	}
+
+	if _, ok, err := client.GetTeamMember("org-1", team.TeamID, "unknown", ""); err != nil || ok {
+		t.Errorf("Expected unknown not to be a member, got %v, %v", ok, err)
+	}
+}
+
+func TestWhoAmI(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+
+	user, err := client.WhoAmI()
+	if err != nil {
+		t.Fatalf("WhoAmI failed: %v", err)
+	}
+	if user.AccountID != "fake-account" || user.Email != "fake@example.com" {
+		t.Errorf("Unexpected authenticated user: %+v", user)
+	}
+}
+
+func TestGetAllTeamsResumesFromFailedPage(t *testing.T) {
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "page2" {
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(PublicApiTeamPaginationResult{Entities: []Team{{TeamID: "t2"}}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(PublicApiTeamPaginationResult{Cursor: "page2", Entities: []Team{{TeamID: "t1"}}})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	teams, err := client.GetAllTeams("org", "", "")
+	var pageErr *TeamsPageError
+	if !errors.As(err, &pageErr) || pageErr.Cursor != "page2" {
+		t.Fatalf("Expected a TeamsPageError at cursor page2, got %v", err)
+	}
+	if len(teams) != 1 || teams[0].TeamID != "t1" {
+		t.Errorf("Expected the first page to be kept, got %+v", teams)
+	}
+
+	rest, err := client.GetAllTeams("org", "", pageErr.Cursor)
+	if err != nil || len(rest) != 1 || rest[0].TeamID != "t2" {
+		t.Errorf("Expected resuming to return the remaining team, got %+v, %v", rest, err)
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	newResponse := func(status int, contentType, body string) *http.Response {
+		recorder := httptest.NewRecorder()
+		recorder.Header().Set("Content-Type", contentType)
+		recorder.WriteHeader(status)
+		_, _ = recorder.WriteString(body)
+		return recorder.Result()
+	}
+
+	var team Team
+	if err := decodeResponse(newResponse(http.StatusNoContent, "", ""), &team); err != nil {
+		t.Errorf("Expected a 204 to decode cleanly, got %v", err)
+	}
+	if err := decodeResponse(newResponse(http.StatusOK, "application/json", "  \n"), &team); err != nil {
+		t.Errorf("Expected an empty body to decode cleanly, got %v", err)
+	}
+	if err := decodeResponse(newResponse(http.StatusOK, "application/json", `{"teamId":"t1"}`), &team); err != nil || team.TeamID != "t1" {
+		t.Errorf("Expected team t1, got %+v, %v", team, err)
+	}
+
+	err := decodeResponse(newResponse(http.StatusOK, "text/html", "<html>Bad Gateway</html>"), &team)
+	if err == nil || !strings.Contains(err.Error(), "text/html") {
+		t.Errorf("Expected an HTML body to fail naming its content type, got %v", err)
+	}
+}
+
+func TestWaitForTeamMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 5 * time.Millisecond
+
+	team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+	expected := []TeamMember{{AccountID: "a"}}
+	if _, err := client.AddTeamMembers("org-1", team.TeamID, expected); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	fake.delayMemberVisibility(2)
+	members, err := client.WaitForTeamMembers("org-1", team.TeamID, "", expected, time.Second)
+	if err != nil || len(members) != 1 {
+		t.Fatalf("Expected the member once visible, got %v, %v", members, err)
+	}
+	if got := len(fake.requestsMatching("/members")) - len(fake.requestsMatching("/members/add")); got != 3 {
+		t.Errorf("Expected 3 member fetches, got %d", got)
+	}
+
+	fake.delayMemberVisibility(1000)
+	if _, err := client.WaitForTeamMembers("org-1", team.TeamID, "", expected, 20*time.Millisecond); !errors.Is(err, ErrTeamMembersNotVisible) {
+		t.Errorf("Expected ErrTeamMembersNotVisible after the timeout, got %v", err)
+	}
+
+	// The waits count against the retry budget
+	client.SetMaxRetryDuration(0)
+	before := len(fake.requestsMatching("/members"))
+	if _, err := client.WaitForTeamMembers("org-1", team.TeamID, "", expected, time.Second); !errors.Is(err, ErrTeamMembersNotVisible) {
+		t.Errorf("Expected ErrTeamMembersNotVisible once the budget is used up, got %v", err)
+	}
+	if got := len(fake.requestsMatching("/members")) - before; got != 1 {
+		t.Errorf("Expected a single member fetch with an empty budget, got %d", got)
+	}
+}
+
+func TestGzipResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Expected Accept-Encoding gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		if r.URL.Path == "/public/teams/v1/org/org/teams/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = gz.Write([]byte(`{"code":"NOT_FOUND","message":"team not found"}`))
+			return
+		}
+		_, _ = gz.Write([]byte(`{"entities":[{"teamId":"t1","displayName":"Platform"}],"cursor":""}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	result, err := client.GetTeams("org", "", 10, "", nil)
+	if err != nil {
+		t.Fatalf("GetTeams failed: %v", err)
+	}
+	if len(result.Entities) != 1 || result.Entities[0].DisplayName != "Platform" {
+		t.Errorf("Expected the decompressed team, got %+v", result.Entities)
+	}
+
+	_, err = client.GetTeam("missing")
+	if !isNotFoundError(err) || !strings.Contains(err.Error(), "team not found") {
+		t.Errorf("Expected a decoded not found error, got %v", err)
+	}
+
+	if err := client.DeleteTeam("t1"); err != nil {
+		t.Errorf("Expected an empty gzip-marked response to be accepted, got %v", err)
+	}
+}
+
+func TestGetTeamsDefaultsToTheMaximumPageSize(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PublicApiTeamPaginationResult{Entities: []Team{}})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	for _, size := range []int32{0, 50, 1000} {
+		if _, err := client.GetTeams("org", "", size, "", nil); err != nil {
+			t.Fatalf("GetTeams failed: %v", err)
+		}
+	}
+	if want := []string{"size=300", "size=50", "size=300"}; !slices.Equal(queries, want) {
+		t.Errorf("Expected queries %v, got %v", want, queries)
+	}
+}
+
+func TestWithAPITokenAuthenticatesCopyOnly(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PublicApiTeamPaginationResult{Entities: []Team{}})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("write-token", "", "", "", "org", server.URL)
+	readClient := client.WithAPIToken("read-token")
+	if client.WithAPIToken("") != client {
+		t.Error("Expected an empty token to return the client itself")
+	}
+
+	_, _ = readClient.GetTeams("org", "", 0, "", nil)
+	_, _ = client.GetTeams("org", "", 0, "", nil)
+	if want := []string{"Bearer read-token", "Bearer write-token"}; !slices.Equal(auth, want) {
+		t.Errorf("Expected Authorization headers %v, got %v", want, auth)
+	}
+}
+
+func TestGetArchivedTeams(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	fake.mu.Lock()
+	for teamID, state := range map[string]string{"t1": "ACTIVE", "t2": "ARCHIVED", "t3": "ARCHIVED", "t4": "DISBANDED"} {
+		fake.teams[teamID] = &TeamResponse{TeamID: teamID, State: state}
+	}
+	fake.mu.Unlock()
+
+	teams, err := client.GetArchivedTeams("org-1", "")
+	if err != nil {
+		t.Fatalf("GetArchivedTeams failed: %v", err)
+	}
+	teamIDs := make([]string, len(teams))
+	for i, team := range teams {
+		teamIDs[i] = team.TeamID
+	}
+	slices.Sort(teamIDs)
+	if want := []string{"t2", "t3"}; !slices.Equal(teamIDs, want) {
+		t.Errorf("Expected archived teams %v, got %v", want, teamIDs)
+	}
+}
+
+func TestResponseBodySizeIsLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(strings.Repeat("x", maxResponseBodySize+1)))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	_, err := client.GetTeam("t1")
+	if !errors.Is(err, ErrResponseBodyTooLarge) {
+		t.Errorf("Expected ErrResponseBodyTooLarge, got %v", err)
+	}
+
+	body, err := readResponseBody(strings.NewReader(`{"teamId":"t1"}`))
+	if err != nil || string(body) != `{"teamId":"t1"}` {
+		t.Errorf("Expected a small body to be read in full, got %q, %v", body, err)
+	}
+}
+
+func TestExtraHeaders(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[],"pageInfo":{}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.ExtraHeaders = map[string]string{
+		"X-ExperimentalApi": "opt-in",
+		"Accept":            "application/xml",
+		"Authorization":     "Bearer stolen",
+	}
+
+	_, _ = client.GetTeam("t1")
+	_, _ = client.FetchTeamMembers("org", "t1", "", "", 0)
+
+	for _, header := range headers {
+		if got := header.Get("X-ExperimentalApi"); got != "opt-in" {
+			t.Errorf("Expected the extra header on every request, got %q", got)
+		}
+		if got := header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Expected Authorization not to be overridden, got %q", got)
+		}
+	}
+	if got := headers[1].Get("Accept"); got != acceptAny {
+		t.Errorf("Expected the endpoint Accept header to take precedence, got %q", got)
+	}
+}
+
+func TestGetTeamWithMembersFetchesConcurrently(t *testing.T) {
+	membersRequested := make(chan struct{}, 1)
+	var membersStatus, teamStatus atomic.Int32
+	membersStatus.Store(http.StatusOK)
+	teamStatus.Store(http.StatusOK)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/members") {
+			select {
+			case membersRequested <- struct{}{}:
+			default:
+			}
+			w.WriteHeader(int(membersStatus.Load()))
+			_, _ = w.Write([]byte(`{"results":[{"accountId":"a"}],"pageInfo":{}}`))
+			return
+		}
+
+		// The team is only returned once the member fetch has started
+		select {
+		case <-membersRequested:
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("Expected the member fetch to run while the team is fetched")
+		}
+		w.WriteHeader(int(teamStatus.Load()))
+		_, _ = w.Write([]byte(`{"teamId":"t1","displayName":"Platform"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+	client.MaxRetries = 0
+
+	team, err := client.GetTeamWithMembers("t1", "")
+	if err != nil {
+		t.Fatalf("GetTeamWithMembers failed: %v", err)
+	}
+	if team.DisplayName != "Platform" || len(team.Members) != 1 {
+		t.Errorf("Expected the team with its member, got %+v", team)
+	}
+
+	membersStatus.Store(http.StatusInternalServerError)
+	if _, err := client.GetTeamWithMembers("t1", ""); err == nil || isNotFoundError(err) {
+		t.Errorf("Expected the member fetch error, got %v", err)
+	}
+
+	membersStatus.Store(http.StatusOK)
+	teamStatus.Store(http.StatusNotFound)
+	if _, err := client.GetTeamWithMembers("t1", ""); !isNotFoundError(err) {
+		t.Errorf("Expected the team fetch error, got %v", err)
+	}
+}
+
+func TestGetTeamWithMembersFallsBackWhenOnlyMembersAreMissing(t *testing.T) {
+	var mu sync.Mutex
+	var memberQueries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/members") {
+			mu.Lock()
+			memberQueries = append(memberQueries, r.URL.RawQuery)
+			mu.Unlock()
+			// The members are only found without the site scope
+			if r.URL.Query().Get("siteId") != "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"results":[{"accountId":"a"}],"pageInfo":{}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"teamId":"t1","displayName":"Platform"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "site-1", "org", server.URL)
+	client.MaxRetries = 0
+
+	team, err := client.GetTeamWithMembers("t1", "")
+	if err != nil {
+		t.Fatalf("Expected a 404 of the scoped member fetch to fall back, got: %v", err)
+	}
+	if team.TeamID != "t1" || len(team.Members) != 1 {
+		t.Errorf("Expected the team with its member, got %+v", team)
+	}
+	if len(memberQueries) != 2 || !strings.Contains(memberQueries[0], "siteId=site-1") || strings.Contains(memberQueries[1], "siteId") {
+		t.Errorf("Expected a scoped then an unscoped member fetch, got %q", memberQueries)
+	}
+}
+
+func TestGetTeamWithMembersReadsMembersInTheTeamScope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		scoped := r.URL.Query().Get("siteId") != ""
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/members") && scoped:
+			// The site does not scope the team, so it lists no members
+			_, _ = w.Write([]byte(`{"results":[],"pageInfo":{}}`))
+		case strings.HasSuffix(r.URL.Path, "/members"):
+			_, _ = w.Write([]byte(`{"results":[{"accountId":"a"}],"pageInfo":{}}`))
+		case scoped:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{"teamId":"t1","displayName":"Platform"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "site-1", "org", server.URL)
+	client.MaxRetries = 0
+
+	team, err := client.GetTeamWithMembers("t1", "")
+	if err != nil {
+		t.Fatalf("GetTeamWithMembers failed: %v", err)
+	}
+	if len(team.Members) != 1 || team.Members[0].AccountID != "a" {
+		t.Errorf("Expected the members of the unscoped team, got %+v", team.Members)
+	}
+}
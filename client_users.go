@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// User represents an Atlassian account profile
+type User struct {
+	AccountID string `json:"account_id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+}
+
+// userProfileResponse matches the response of the user management profile endpoint
+type userProfileResponse struct {
+	Account User `json:"account"`
+}
+
+// GetUser retrieves the profile of an account, including its email address
+func (c *AtlassianClient) GetUser(accountID string) (*User, error) {
+	path := fmt.Sprintf("/users/%s/manage/profile", url.PathEscape(accountID))
+
+	resp, err := c.makeRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("getting user", resp)
+	}
+
+	var profile userProfileResponse
+	if err := decodeResponse(resp, &profile); err != nil {
+		return nil, fmt.Errorf("error decoding user response: %w", err)
+	}
+
+	return &profile.Account, nil
+}
+
+// WhoAmI retrieves the account the client authenticates as through the user
+// identity endpoint. Atlassian only serves it for OAuth 2.0 (3LO) access
+// tokens with the read:me scope; other credentials are rejected.
+func (c *AtlassianClient) WhoAmI() (*User, error) {
+	resp, err := c.makeRequest("GET", "/me", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting authenticated user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("getting authenticated user", resp)
+	}
+
+	var user User
+	if err := decodeResponse(resp, &user); err != nil {
+		return nil, fmt.Errorf("error decoding authenticated user response: %w", err)
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TeamDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &TeamDataSource{}
+
+func NewTeamDataSource() datasource.DataSource {
+	return &TeamDataSource{}
+}
+
+// TeamDataSource defines the data source implementation.
+type TeamDataSource struct {
+	client *AtlassianClient
+}
+
+// TeamDataSourceModel describes the data source data model.
+type TeamDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	IgnoreCase     types.Bool   `tfsdk:"ignore_case"`
+	SiteId         types.String `tfsdk:"site_id"`
+	DisplayName    types.String `tfsdk:"display_name"`
+	Description    types.String `tfsdk:"description"`
+	TeamType       types.String `tfsdk:"team_type"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	CreatorId      types.String `tfsdk:"creator_id"`
+	State          types.String `tfsdk:"state"`
+	RawJSON        types.String `tfsdk:"raw_json"`
+}
+
+func (d *TeamDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team"
+}
+
+func (d *TeamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an existing Atlassian team by ID or display name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Team identifier. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Team display name to look up. Exactly one of `id` or `name` must be set. The lookup fails if zero or multiple teams match.",
+				Optional:            true,
+			},
+			"ignore_case": schema.BoolAttribute{
+				MarkdownDescription: "Match `name` case-insensitively. Defaults to `false`.",
+				Optional:            true,
+			},
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "Site identifier used to scope the name lookup. Defaults to the provider site_id.",
+				Optional:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Team display name",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Team description",
+				Computed:            true,
+			},
+			"team_type": schema.StringAttribute{
+				MarkdownDescription: "Team type (OPEN, MEMBER_INVITE, EXTERNAL, ORG_ADMIN_MANAGED)",
+				Computed:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization identifier",
+				Computed:            true,
+			},
+			"creator_id": schema.StringAttribute{
+				MarkdownDescription: "Creator identifier",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Team state (ACTIVE, ARCHIVED, etc.)",
+				Computed:            true,
+			},
+			"raw_json": schema.StringAttribute{
+				MarkdownDescription: "Raw JSON of the team response from the API, to help report API changes. Only set when the provider enables `expose_raw_json`; " +
+					"a lookup by name then reads the team by ID once more.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *TeamDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *TeamDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TeamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TeamDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Name.IsNull() {
+		siteId := d.client.SiteId
+		if !data.SiteId.IsNull() {
+			siteId = data.SiteId.ValueString()
+		}
+
+		var team *Team
+		var err error
+		if data.IgnoreCase.ValueBool() {
+			team, err = d.client.WithContext(ctx).GetTeamByNameIgnoreCase(d.client.getOrgIdentifier(), siteId, data.Name.ValueString())
+		} else {
+			team, err = d.client.WithContext(ctx).GetTeamByName(d.client.getOrgIdentifier(), siteId, data.Name.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Client Error", fmt.Sprintf("Unable to find team by name, got error: %s", err)+permissionHint(err))
+			return
+		}
+
+		data.ID = types.StringValue(team.TeamID)
+		data.DisplayName = types.StringValue(team.DisplayName)
+		data.Description = types.StringValue(team.Description)
+		data.TeamType = types.StringValue(team.TeamType)
+		data.OrganizationId = types.StringValue(team.OrganizationId)
+		data.CreatorId = types.StringValue(team.CreatorId)
+		data.State = types.StringValue(team.State)
+
+		// The list endpoint returns a different shape, read the team itself for its raw JSON
+		data.RawJSON = types.StringNull()
+		if d.client.ExposeRawJSON {
+			teamResponse, err := d.client.WithContext(ctx).GetTeam(team.TeamID)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team, got error: %s", err)+permissionHint(err))
+				return
+			}
+			data.RawJSON = rawJSONValue(d.client, teamResponse.RawJSON)
+		}
+	} else {
+		team, err := d.client.WithContext(ctx).GetTeam(data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team, got error: %s", err)+permissionHint(err))
+			return
+		}
+
+		data.ID = types.StringValue(team.TeamID)
+		data.DisplayName = types.StringValue(team.DisplayName)
+		data.Description = types.StringValue(team.Description)
+		data.TeamType = types.StringValue(team.TeamType)
+		data.OrganizationId = types.StringValue(team.OrganizationId)
+		data.CreatorId = types.StringValue(team.CreatorId)
+		data.State = types.StringValue(team.State)
+		data.RawJSON = rawJSONValue(d.client, team.RawJSON)
+	}
+
+	tflog.Trace(ctx, "read a team data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
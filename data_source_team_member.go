@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TeamMemberDataSource{}
+
+func NewTeamMemberDataSource() datasource.DataSource {
+	return &TeamMemberDataSource{}
+}
+
+// TeamMemberDataSource defines the data source implementation.
+type TeamMemberDataSource struct {
+	client *AtlassianClient
+}
+
+// TeamMemberDataSourceModel describes the data source data model.
+type TeamMemberDataSourceModel struct {
+	TeamID    types.String `tfsdk:"team_id"`
+	AccountID types.String `tfsdk:"account_id"`
+	SiteId    types.String `tfsdk:"site_id"`
+	IsMember  types.Bool   `tfsdk:"is_member"`
+}
+
+func (d *TeamMemberDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_member"
+}
+
+func (d *TeamMemberDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks whether an account is a member of an Atlassian team. The Teams API has no lookup by account, " +
+			"so members are paged 50 at a time until the account is found: checking a non-member of a large team reads all of its members.",
+
+		Attributes: map[string]schema.Attribute{
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "Team identifier",
+				Required:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Account ID to look for",
+				Required:            true,
+				Validators: []validator.String{
+					accountIdValidator{},
+				},
+			},
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "Site identifier used to scope the requests. Defaults to the provider site_id.",
+				Optional:            true,
+			},
+			"is_member": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is a member of the team",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TeamMemberDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TeamMemberDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TeamMemberDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.client.WithContext(ctx)
+	_, isMember, err := client.GetTeamMember(client.getOrgIdentifier(), data.TeamID.ValueString(), data.AccountID.ValueString(), data.SiteId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team members, got error: %s", err)+permissionHint(err))
+		return
+	}
+	data.IsMember = types.BoolValue(isMember)
+
+	tflog.Trace(ctx, "read a team member data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TeamMembershipSummaryDataSource{}
+
+func NewTeamMembershipSummaryDataSource() datasource.DataSource {
+	return &TeamMembershipSummaryDataSource{}
+}
+
+// TeamMembershipSummaryDataSource defines the data source implementation.
+type TeamMembershipSummaryDataSource struct {
+	client *AtlassianClient
+}
+
+// TeamMembershipSummaryDataSourceModel describes the data source data model.
+type TeamMembershipSummaryDataSourceModel struct {
+	TeamIDs types.List                       `tfsdk:"team_ids"`
+	SiteId  types.String                     `tfsdk:"site_id"`
+	Teams   []TeamMembershipSummaryTeamModel `tfsdk:"teams"`
+}
+
+// TeamMembershipSummaryTeamModel describes the member count of a single team.
+type TeamMembershipSummaryTeamModel struct {
+	TeamID      types.String `tfsdk:"team_id"`
+	MemberCount types.Int64  `tfsdk:"member_count"`
+}
+
+func (d *TeamMembershipSummaryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_membership_summary"
+}
+
+func (d *TeamMembershipSummaryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Counts the members of Atlassian teams. The Teams API has no count endpoint and reports no totals, " +
+			"so members are paged 50 at a time: a read costs one call per team plus one per additional 50 members.",
+
+		Attributes: map[string]schema.Attribute{
+			"team_ids": schema.ListAttribute{
+				MarkdownDescription: "Teams to count the members of. Defaults to all teams of the organization.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "Site identifier used to scope the requests. Defaults to the provider site_id.",
+				Optional:            true,
+			},
+			"teams": schema.ListNestedAttribute{
+				MarkdownDescription: "Member count of each team",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"team_id": schema.StringAttribute{
+							MarkdownDescription: "Team identifier",
+							Computed:            true,
+						},
+						"member_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of members of the team",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TeamMembershipSummaryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TeamMembershipSummaryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TeamMembershipSummaryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.client.WithContext(ctx)
+
+	siteId := client.SiteId
+	if !data.SiteId.IsNull() {
+		siteId = data.SiteId.ValueString()
+	}
+
+	var teamIDs []string
+	if !data.TeamIDs.IsNull() {
+		resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		teams, err := client.GetAllTeams(client.getOrgIdentifier(), siteId, "")
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err)+permissionHint(err))
+			return
+		}
+		for _, team := range teams {
+			teamIDs = append(teamIDs, team.TeamID)
+		}
+	}
+
+	data.Teams = make([]TeamMembershipSummaryTeamModel, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		count, err := client.CountTeamMembers(client.getOrgIdentifier(), teamID, siteId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to count members of team %s, got error: %s", teamID, err)+permissionHint(err))
+			return
+		}
+		data.Teams = append(data.Teams, TeamMembershipSummaryTeamModel{
+			TeamID:      types.StringValue(teamID),
+			MemberCount: types.Int64Value(int64(count)),
+		})
+	}
+
+	tflog.Trace(ctx, "read a team membership summary data source", map[string]any{"count": len(data.Teams)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTeamMembershipSummaryDataSource(t *testing.T) {
+	ctx := context.Background()
+	_, client := newFakeAtlassianServer(t, "org-1")
+
+	counts := map[string]int{}
+	for i, name := range []string{"Platform", "Security"} {
+		team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: name, TeamType: "OPEN"})
+		if err != nil {
+			t.Fatalf("CreateTeam failed: %v", err)
+		}
+		members := []TeamMember{{AccountID: "a"}, {AccountID: "b"}, {AccountID: "c"}}[:i+1]
+		if _, err := client.AddTeamMembers("org-1", team.TeamID, members); err != nil {
+			t.Fatalf("AddTeamMembers failed: %v", err)
+		}
+		counts[team.TeamID] = len(members)
+	}
+
+	d := &TeamMembershipSummaryDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	config := TeamMembershipSummaryDataSourceModel{TeamIDs: types.ListNull(types.StringType), SiteId: types.StringNull()}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("Unable to build config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: state}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", resp.Diagnostics)
+	}
+
+	var data TeamMembershipSummaryDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if len(data.Teams) != len(counts) {
+		t.Fatalf("Expected %d teams, got %+v", len(counts), data.Teams)
+	}
+	for _, team := range data.Teams {
+		if want := counts[team.TeamID.ValueString()]; team.MemberCount.ValueInt64() != int64(want) {
+			t.Errorf("Expected %d members for team %s, got %d", want, team.TeamID.ValueString(), team.MemberCount.ValueInt64())
+		}
+	}
+}
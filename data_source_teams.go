@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TeamsDataSource{}
+
+func NewTeamsDataSource() datasource.DataSource {
+	return &TeamsDataSource{}
+}
+
+// TeamsDataSource defines the data source implementation.
+type TeamsDataSource struct {
+	client *AtlassianClient
+}
+
+// TeamsDataSourceModel describes the data source data model.
+type TeamsDataSourceModel struct {
+	SiteId      types.String          `tfsdk:"site_id"`
+	Limit       types.Int64           `tfsdk:"limit"`
+	PageSize    types.Int64           `tfsdk:"page_size"`
+	Cursor      types.String          `tfsdk:"cursor"`
+	FilterType  types.String          `tfsdk:"filter_type"`
+	FilterState types.String          `tfsdk:"filter_state"`
+	NextCursor  types.String          `tfsdk:"next_cursor"`
+	Teams       []TeamsDataSourceTeam `tfsdk:"teams"`
+}
+
+// TeamsDataSourceTeam describes a single team in the data source.
+type TeamsDataSourceTeam struct {
+	ID             types.String `tfsdk:"id"`
+	DisplayName    types.String `tfsdk:"display_name"`
+	Description    types.String `tfsdk:"description"`
+	TeamType       types.String `tfsdk:"team_type"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	CreatorId      types.String `tfsdk:"creator_id"`
+	State          types.String `tfsdk:"state"`
+}
+
+func (d *TeamsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_teams"
+}
+
+func (d *TeamsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the teams of the Atlassian organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "Site identifier used to scope the listing. Defaults to the provider site_id.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Stop paging once this many teams have been read. This is a safety bound on the size of the read, not a filter: which teams are returned depends on the API's ordering.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of teams requested per page. Values above the API maximum of 300 are clamped.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"cursor": schema.StringAttribute{
+				MarkdownDescription: "When set, only the single page starting at this cursor is read instead of all pages. Use an empty string for the first page and `next_cursor` of the previous read for the following ones.",
+				Optional:            true,
+			},
+			"filter_type": schema.StringAttribute{
+				MarkdownDescription: "Only return teams of this type (OPEN, MEMBER_INVITE, EXTERNAL, ORG_ADMIN_MANAGED). " +
+					"Filtering happens client-side, so all pages are still fetched.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(teamTypes...),
+				},
+			},
+			"filter_state": schema.StringAttribute{
+				MarkdownDescription: "Only return teams in this state, e.g. ACTIVE or ARCHIVED. Filtering happens client-side, so all pages are still fetched.",
+				Optional:            true,
+			},
+			"next_cursor": schema.StringAttribute{
+				MarkdownDescription: "Cursor of the page following the last page that was read, or an empty string if there are no more teams. " +
+					"If `limit` stops the read mid-page, the remaining teams of that page are skipped by this cursor.",
+				Computed: true,
+			},
+			"teams": schema.ListNestedAttribute{
+				MarkdownDescription: "Teams of the organization",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Team identifier",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Team display name",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Team description",
+							Computed:            true,
+						},
+						"team_type": schema.StringAttribute{
+							MarkdownDescription: "Team type (OPEN, MEMBER_INVITE, EXTERNAL, ORG_ADMIN_MANAGED)",
+							Computed:            true,
+						},
+						"organization_id": schema.StringAttribute{
+							MarkdownDescription: "Organization identifier",
+							Computed:            true,
+						},
+						"creator_id": schema.StringAttribute{
+							MarkdownDescription: "Creator identifier",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "Team state (ACTIVE, ARCHIVED, etc.)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TeamsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TeamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TeamsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	siteId := d.client.SiteId
+	if !data.SiteId.IsNull() {
+		siteId = data.SiteId.ValueString()
+	}
+
+	// GetTeams clamps the page size to the API maximum
+	pageSize := int32(min(data.PageSize.ValueInt64(), maxTeamsPerPage))
+	limit := int(data.Limit.ValueInt64())
+
+	data.Teams = []TeamsDataSourceTeam{}
+	singlePage := !data.Cursor.IsNull()
+	cursor := data.Cursor.ValueString()
+
+	// A full read of archived teams, e.g. for cleanup, goes through GetArchivedTeams
+	if data.FilterState.ValueString() == "ARCHIVED" && !singlePage && limit <= 0 {
+		teams, err := d.client.WithContext(ctx).GetArchivedTeams(d.client.getOrgIdentifier(), siteId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err)+permissionHint(err))
+			return
+		}
+		for _, team := range teams {
+			if data.FilterType.IsNull() || team.TeamType == data.FilterType.ValueString() {
+				data.Teams = append(data.Teams, teamsDataSourceTeam(team))
+			}
+		}
+		data.NextCursor = types.StringValue("")
+
+		tflog.Trace(ctx, "read a teams data source", map[string]any{"count": len(data.Teams)})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	for {
+		page, err := d.client.WithContext(ctx).GetTeams(d.client.getOrgIdentifier(), siteId, pageSize, cursor, nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err)+permissionHint(err))
+			return
+		}
+
+		data.NextCursor = types.StringValue(page.Cursor)
+
+		for _, team := range page.Entities {
+			if limit > 0 && len(data.Teams) >= limit {
+				break
+			}
+			// The list endpoint has no type or state filters
+			if !data.FilterType.IsNull() && team.TeamType != data.FilterType.ValueString() {
+				continue
+			}
+			if !data.FilterState.IsNull() && team.State != data.FilterState.ValueString() {
+				continue
+			}
+			data.Teams = append(data.Teams, teamsDataSourceTeam(team))
+		}
+
+		if singlePage || (limit > 0 && len(data.Teams) >= limit) || page.Cursor == "" || page.Cursor == cursor {
+			break
+		}
+		cursor = page.Cursor
+	}
+
+	tflog.Trace(ctx, "read a teams data source", map[string]any{"count": len(data.Teams)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// teamsDataSourceTeam converts a listed team into its data source model
+func teamsDataSourceTeam(team Team) TeamsDataSourceTeam {
+	return TeamsDataSourceTeam{
+		ID:             types.StringValue(team.TeamID),
+		DisplayName:    types.StringValue(team.DisplayName),
+		Description:    types.StringValue(team.Description),
+		TeamType:       types.StringValue(team.TeamType),
+		OrganizationId: types.StringValue(team.OrganizationId),
+		CreatorId:      types.StringValue(team.CreatorId),
+		State:          types.StringValue(team.State),
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readTeamsDataSource runs TeamsDataSource.Read with the given configuration
+func readTeamsDataSource(t *testing.T, client *AtlassianClient, config TeamsDataSourceModel) (TeamsDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := &TeamsDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("Unable to build config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: state}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+
+	var data TeamsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+	return data, resp
+}
+
+func TestTeamsDataSourceLimitAndPageSize(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		page := len(requests)
+		_ = json.NewEncoder(w).Encode(PublicApiTeamPaginationResult{
+			Cursor: fmt.Sprintf("page%d", page+1),
+			Entities: []Team{
+				{TeamID: fmt.Sprintf("t%d-1", page)},
+				{TeamID: fmt.Sprintf("t%d-2", page)},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	data, resp := readTeamsDataSource(t, client, TeamsDataSourceModel{
+		SiteId:   types.StringNull(),
+		Limit:    types.Int64Value(3),
+		PageSize: types.Int64Value(1000),
+		Cursor:   types.StringNull(),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", resp.Diagnostics)
+	}
+
+	if len(data.Teams) != 3 {
+		t.Errorf("Expected limit to cap the result at 3 teams, got %d", len(data.Teams))
+	}
+	if len(requests) != 2 {
+		t.Errorf("Expected paging to stop after 2 requests, got %d", len(requests))
+	}
+	if requests[0] != "size=300" {
+		t.Errorf("Expected page_size to be clamped to 300, got query %q", requests[0])
+	}
+}
+
+func TestTeamsDataSourceSinglePageCursor(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("cursor"))
+		_ = json.NewEncoder(w).Encode(PublicApiTeamPaginationResult{
+			Cursor:   "page3",
+			Entities: []Team{{TeamID: "t1"}},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", server.URL)
+
+	data, resp := readTeamsDataSource(t, client, TeamsDataSourceModel{
+		SiteId:   types.StringNull(),
+		Limit:    types.Int64Null(),
+		PageSize: types.Int64Null(),
+		Cursor:   types.StringValue("page2"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", resp.Diagnostics)
+	}
+
+	if len(requests) != 1 || requests[0] != "page2" {
+		t.Errorf("Expected a single request for cursor page2, got %q", requests)
+	}
+	if data.NextCursor.ValueString() != "page3" || len(data.Teams) != 1 {
+		t.Errorf("Expected one team and next_cursor page3, got %d teams and %q", len(data.Teams), data.NextCursor.ValueString())
+	}
+}
+
+func TestTeamsDataSourceFilters(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	var archivedID string
+	for _, teamType := range []string{"OPEN", "OPEN", "MEMBER_INVITE"} {
+		team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Team", TeamType: teamType})
+		if err != nil {
+			t.Fatalf("CreateTeam failed: %v", err)
+		}
+		archivedID = team.TeamID
+	}
+	if _, err := client.ArchiveTeams("org-1", []string{archivedID}); err != nil {
+		t.Fatalf("ArchiveTeams failed: %v", err)
+	}
+
+	tests := []struct {
+		filterType, filterState types.String
+		want                    int
+	}{
+		{types.StringNull(), types.StringNull(), 3},
+		{types.StringValue("OPEN"), types.StringNull(), 2},
+		{types.StringNull(), types.StringValue("ARCHIVED"), 1},
+		{types.StringValue("OPEN"), types.StringValue("ARCHIVED"), 0},
+	}
+	for _, tt := range tests {
+		data, resp := readTeamsDataSource(t, client, TeamsDataSourceModel{
+			FilterType:  tt.filterType,
+			FilterState: tt.filterState,
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Read failed: %v", resp.Diagnostics)
+		}
+		if len(data.Teams) != tt.want {
+			t.Errorf("filter_type=%v filter_state=%v: expected %d teams, got %d", tt.filterType, tt.filterState, tt.want, len(data.Teams))
+		}
+	}
+}
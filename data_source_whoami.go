@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WhoAmIDataSource{}
+
+func NewWhoAmIDataSource() datasource.DataSource {
+	return &WhoAmIDataSource{}
+}
+
+// WhoAmIDataSource defines the data source implementation.
+type WhoAmIDataSource struct {
+	client *AtlassianClient
+}
+
+// WhoAmIDataSourceModel describes the data source data model.
+type WhoAmIDataSourceModel struct {
+	AccountID types.String `tfsdk:"account_id"`
+	Email     types.String `tfsdk:"email"`
+}
+
+func (d *WhoAmIDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+func (d *WhoAmIDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads the account the provider authenticates as for data sources, i.e. the owner of `read_api_token` or the OAuth credentials, " +
+			"e.g. to add it to a team. Atlassian only serves the account identity to OAuth 2.0 (3LO) access tokens with the `read:me` scope; API tokens are rejected.",
+
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Account ID of the authenticated account",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the authenticated account",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WhoAmIDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WhoAmIDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WhoAmIDataSourceModel
+
+	user, err := d.client.WithContext(ctx).WhoAmI()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the authenticated account, got error: %s", err)+permissionHint(err))
+		return
+	}
+
+	data.AccountID = types.StringValue(user.AccountID)
+	data.Email = types.StringValue(user.Email)
+
+	tflog.Trace(ctx, "read a whoami data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
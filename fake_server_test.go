@@ -0,0 +1,537 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAtlassianServer is an in-memory implementation of the Teams API
+// endpoints used by the provider, for tests that should not hit the real API.
+type fakeAtlassianServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	nextID  int
+	teams   map[string]*TeamResponse
+	members map[string][]string // team ID -> account IDs, in insertion order
+	users   map[string]User     // account ID -> profile
+	groups  map[string][]string // group ID -> account IDs, in insertion order
+
+	rejected map[string]bool // account IDs the membership endpoints report errors for
+	requests []string        // "METHOD path" of every request received
+
+	staleFetches    int // number of upcoming member fetches that return no members
+	deleteConflicts int // number of upcoming team deletes that return 409 Conflict
+
+	refuseArchivedDeletes bool // deletes of archived teams return 409 Conflict
+	failStateChanges      bool // archive and unarchive report an error for every team
+}
+
+// newFakeAtlassianServer starts a fake Teams API server and returns it along
+// with a client pointed at it. The server is closed when the test finishes.
+func newFakeAtlassianServer(t *testing.T, orgID string) (*fakeAtlassianServer, *AtlassianClient) {
+	t.Helper()
+
+	f := &fakeAtlassianServer{
+		teams:   make(map[string]*TeamResponse),
+		members: make(map[string][]string),
+		users:   make(map[string]User),
+		groups:  make(map[string][]string),
+
+		rejected: make(map[string]bool),
+	}
+
+	prefix := "/public/teams/v1/org/{orgId}/teams"
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+prefix, f.handleList)
+	mux.HandleFunc("POST "+prefix+"/{$}", f.handleCreate)
+	mux.HandleFunc("GET "+prefix+"/{teamId}", f.handleGet)
+	mux.HandleFunc("PATCH "+prefix+"/{teamId}", f.handlePatch)
+	mux.HandleFunc("DELETE "+prefix+"/{teamId}", f.handleDelete)
+	mux.HandleFunc("POST "+prefix+"/{teamId}/members", f.handleFetchMembers)
+	mux.HandleFunc("POST "+prefix+"/{teamId}/members/add", f.handleAddMembers)
+	mux.HandleFunc("POST "+prefix+"/{teamId}/members/remove", f.handleRemoveMembers)
+	mux.HandleFunc("POST "+prefix+"/archive", f.handleSetState("ARCHIVED"))
+	mux.HandleFunc("POST "+prefix+"/unarchive", f.handleSetState("ACTIVE"))
+	mux.HandleFunc("GET /users/{accountId}/manage/profile", f.handleGetUser)
+	mux.HandleFunc("GET /admin/v1/orgs/{orgId}", f.handleGetOrganization)
+	mux.HandleFunc("GET /me", f.handleGetMe)
+	mux.HandleFunc("GET /ex/jira/{siteId}/rest/api/3/group/member", f.handleGetGroupMembers)
+	mux.HandleFunc("POST /ex/jira/{siteId}/rest/api/3/group/user", f.handleAddGroupUser)
+	mux.HandleFunc("DELETE /ex/jira/{siteId}/rest/api/3/group/user", f.handleRemoveGroupUser)
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		f.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.Close)
+
+	client, err := NewAtlassianClient("test-token", "", "", "", orgID, f.URL)
+	if err != nil {
+		t.Fatalf("Unable to create client for fake server: %v", err)
+	}
+
+	return f, client
+}
+
+// team returns a copy of the stored team, or nil if it does not exist
+func (f *fakeAtlassianServer) team(teamID string) *TeamResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	team, ok := f.teams[teamID]
+	if !ok {
+		return nil
+	}
+	teamCopy := *team
+	return &teamCopy
+}
+
+// teamMembers returns the account IDs of a team's members
+func (f *fakeAtlassianServer) teamMembers(teamID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.members[teamID]...)
+}
+
+// groupMembers returns the account IDs of a group's members
+func (f *fakeAtlassianServer) groupMembers(groupID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.groups[groupID]...)
+}
+
+// setGroupMembers creates a group with the given members
+func (f *fakeAtlassianServer) setGroupMembers(groupID string, accountIDs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.groups[groupID] = append([]string{}, accountIDs...)
+}
+
+// requestsMatching returns the received requests whose "METHOD path" contains substr
+func (f *fakeAtlassianServer) requestsMatching(substr string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matching []string
+	for _, request := range f.requests {
+		if strings.Contains(request, substr) {
+			matching = append(matching, request)
+		}
+	}
+	return matching
+}
+
+// delayMemberVisibility makes the next n member fetches return no members
+func (f *fakeAtlassianServer) delayMemberVisibility(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.staleFetches = n
+}
+
+// rejectAccount makes the membership endpoints report an error for accountID
+func (f *fakeAtlassianServer) rejectAccount(accountID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rejected[accountID] = true
+}
+
+// setUser stores the profile returned for an account
+func (f *fakeAtlassianServer) setUser(user User) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.users[user.AccountID] = user
+}
+
+func (f *fakeAtlassianServer) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (f *fakeAtlassianServer) writeNotFound(w http.ResponseWriter, teamID string) {
+	f.writeJSON(w, http.StatusNotFound, apiErrorBody{Code: "NOT_FOUND", Message: "team not found: " + teamID})
+}
+
+func (f *fakeAtlassianServer) handleList(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := PublicApiTeamPaginationResult{Entities: []Team{}}
+	for _, team := range f.teams {
+		result.Entities = append(result.Entities, Team{
+			TeamID:         team.TeamID,
+			DisplayName:    team.DisplayName,
+			Description:    team.Description,
+			TeamType:       team.TeamType,
+			OrganizationId: team.OrganizationId,
+			CreatorId:      team.CreatorId,
+			State:          team.State,
+		})
+	}
+	f.writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeAtlassianServer) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var createReq CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&createReq); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	team := &TeamResponse{
+		TeamID:         fmt.Sprintf("team-%d", f.nextID),
+		DisplayName:    createReq.DisplayName,
+		Description:    createReq.Description,
+		TeamType:       createReq.TeamType,
+		OrganizationId: r.PathValue("orgId"),
+		CreatorId:      "fake-creator",
+		State:          "ACTIVE",
+	}
+	f.teams[team.TeamID] = team
+
+	f.writeJSON(w, http.StatusCreated, TeamResponseWithMembers{
+		TeamID:         team.TeamID,
+		DisplayName:    team.DisplayName,
+		Description:    team.Description,
+		TeamType:       team.TeamType,
+		OrganizationId: team.OrganizationId,
+		CreatorId:      team.CreatorId,
+		State:          team.State,
+		Members:        []TeamMember{},
+	})
+}
+
+func (f *fakeAtlassianServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	team := f.team(r.PathValue("teamId"))
+	if team == nil {
+		f.writeNotFound(w, r.PathValue("teamId"))
+		return
+	}
+	f.writeJSON(w, http.StatusOK, team)
+}
+
+func (f *fakeAtlassianServer) handlePatch(w http.ResponseWriter, r *http.Request) {
+	var updateReq map[string]*string
+	if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	team, ok := f.teams[r.PathValue("teamId")]
+	if !ok {
+		f.writeNotFound(w, r.PathValue("teamId"))
+		return
+	}
+	if displayName, ok := updateReq["displayName"]; ok && displayName != nil {
+		team.DisplayName = *displayName
+	}
+	if description, ok := updateReq["description"]; ok {
+		team.Description = ""
+		if description != nil {
+			team.Description = *description
+		}
+	}
+	f.writeJSON(w, http.StatusOK, team)
+}
+
+func (f *fakeAtlassianServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	teamID := r.PathValue("teamId")
+	if _, ok := f.teams[teamID]; !ok {
+		f.writeNotFound(w, teamID)
+		return
+	}
+	if f.refuseArchivedDeletes && f.teams[teamID].State == "ARCHIVED" {
+		f.writeJSON(w, http.StatusConflict, apiErrorBody{Code: "CONFLICT", Message: "archived teams cannot be deleted"})
+		return
+	}
+	if f.deleteConflicts > 0 {
+		f.deleteConflicts--
+		f.writeJSON(w, http.StatusConflict, apiErrorBody{Code: "CONFLICT", Message: "team members are still being removed"})
+		return
+	}
+	delete(f.teams, teamID)
+	delete(f.members, teamID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAtlassianServer) handleFetchMembers(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	teamID := r.PathValue("teamId")
+	if _, ok := f.teams[teamID]; !ok {
+		f.writeNotFound(w, teamID)
+		return
+	}
+
+	result := PublicApiFetchResponsePublicApiMembershipAccountId{Results: []TeamMember{}}
+	if f.staleFetches > 0 {
+		// Simulate the member list lagging behind recent additions
+		f.staleFetches--
+		f.writeJSON(w, http.StatusOK, result)
+		return
+	}
+
+	// Cursors are the index of the next member; pages hold at most 50 members
+	var payload PublicApiMembershipFetchPayload
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+	if payload.First > maxMembersPerPage {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: "first must be at most 50"})
+		return
+	}
+	if payload.First == 0 {
+		payload.First = maxMembersPerPage
+	}
+	members := f.members[teamID]
+	start, _ := strconv.Atoi(payload.After)
+	start = min(start, len(members))
+	end := min(start+int(payload.First), len(members))
+	for _, accountID := range members[start:end] {
+		result.Results = append(result.Results, TeamMember{AccountID: accountID})
+	}
+	if end < len(members) {
+		result.PageInfo = PublicApiPageInfoAccountId{EndCursor: strconv.Itoa(end), HasNextPage: true}
+	}
+	f.writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeAtlassianServer) handleAddMembers(w http.ResponseWriter, r *http.Request) {
+	var payload PublicApiMembershipAddPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: err.Error()})
+		return
+	}
+	if !validMembersPayload(payload.Members) {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: "members must contain 1 to 50 unique items"})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	teamID := r.PathValue("teamId")
+	if _, ok := f.teams[teamID]; !ok {
+		f.writeNotFound(w, teamID)
+		return
+	}
+
+	result := PublicApiMembershipAddResponse{Errors: []PublicApiMembershipCodedError{}, Members: []TeamMember{}}
+	for _, member := range payload.Members {
+		if f.rejected[member.AccountID] {
+			result.Errors = append(result.Errors, PublicApiMembershipCodedError{AccountID: member.AccountID, Code: "USER_NOT_FOUND", Message: "user not found"})
+			continue
+		}
+		if !slices.Contains(f.members[teamID], member.AccountID) {
+			f.members[teamID] = append(f.members[teamID], member.AccountID)
+		}
+		result.Members = append(result.Members, member)
+	}
+	f.writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeAtlassianServer) handleRemoveMembers(w http.ResponseWriter, r *http.Request) {
+	var payload PublicApiMembershipRemovePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: err.Error()})
+		return
+	}
+	if !validMembersPayload(payload.Members) {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: "members must contain 1 to 50 unique items"})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	teamID := r.PathValue("teamId")
+	if _, ok := f.teams[teamID]; !ok {
+		f.writeNotFound(w, teamID)
+		return
+	}
+
+	result := PublicApiMembershipRemoveResponse{Errors: []PublicApiMembershipCodedError{}}
+	for _, member := range payload.Members {
+		if f.rejected[member.AccountID] {
+			result.Errors = append(result.Errors, PublicApiMembershipCodedError{AccountID: member.AccountID, Code: "FORBIDDEN", Message: "account cannot be removed"})
+			continue
+		}
+		if !slices.Contains(f.members[teamID], member.AccountID) {
+			result.Errors = append(result.Errors, PublicApiMembershipCodedError{AccountID: member.AccountID, Code: "NOT_A_MEMBER", Message: "account is not a member of the team"})
+			continue
+		}
+		remaining := f.members[teamID][:0]
+		for _, accountID := range f.members[teamID] {
+			if accountID != member.AccountID {
+				remaining = append(remaining, accountID)
+			}
+		}
+		f.members[teamID] = remaining
+	}
+	f.writeJSON(w, http.StatusOK, result)
+}
+
+// validMembersPayload reports whether members holds 1 to 50 unique account
+// IDs, mirroring the constraints of the membership endpoints
+func validMembersPayload(members []TeamMember) bool {
+	if len(members) == 0 || len(members) > 50 {
+		return false
+	}
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		if seen[member.AccountID] {
+			return false
+		}
+		seen[member.AccountID] = true
+	}
+	return true
+}
+
+func (f *fakeAtlassianServer) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users[r.PathValue("accountId")]
+	if !ok {
+		f.writeJSON(w, http.StatusNotFound, apiErrorBody{Code: "NOT_FOUND", Message: "user not found"})
+		return
+	}
+	f.writeJSON(w, http.StatusOK, userProfileResponse{Account: user})
+}
+
+func (f *fakeAtlassianServer) handleGetMe(w http.ResponseWriter, r *http.Request) {
+	f.writeJSON(w, http.StatusOK, User{AccountID: "fake-account", Email: "fake@example.com", Name: "Fake Account"})
+}
+
+func (f *fakeAtlassianServer) handleGetOrganization(w http.ResponseWriter, r *http.Request) {
+	var orgResponse organizationResponse
+	orgResponse.Data.ID = r.PathValue("orgId")
+	orgResponse.Data.Type = "orgs"
+	orgResponse.Data.Attributes.Name = "Fake Org " + r.PathValue("orgId")
+	f.writeJSON(w, http.StatusOK, orgResponse)
+}
+
+func (f *fakeAtlassianServer) handleGetGroupMembers(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	groupID := r.URL.Query().Get("groupId")
+	members, ok := f.groups[groupID]
+	if !ok {
+		f.writeJSON(w, http.StatusNotFound, apiErrorBody{Code: "NOT_FOUND", Message: "group not found: " + groupID})
+		return
+	}
+
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	end := min(startAt+maxResults, len(members))
+
+	var page groupMemberPage
+	page.IsLast = end == len(members)
+	for _, accountID := range members[min(startAt, end):end] {
+		page.Values = append(page.Values, struct {
+			AccountID string `json:"accountId"`
+		}{accountID})
+	}
+	f.writeJSON(w, http.StatusOK, page)
+}
+
+func (f *fakeAtlassianServer) handleAddGroupUser(w http.ResponseWriter, r *http.Request) {
+	var payload groupUserPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	groupID := r.URL.Query().Get("groupId")
+	members, ok := f.groups[groupID]
+	switch {
+	case !ok:
+		f.writeJSON(w, http.StatusNotFound, apiErrorBody{Code: "NOT_FOUND", Message: "group not found: " + groupID})
+	case f.rejected[payload.AccountID]:
+		f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "USER_NOT_FOUND", Message: "user not found"})
+	default:
+		if !slices.Contains(members, payload.AccountID) {
+			f.groups[groupID] = append(members, payload.AccountID)
+		}
+		f.writeJSON(w, http.StatusCreated, map[string]string{"groupId": groupID})
+	}
+}
+
+func (f *fakeAtlassianServer) handleRemoveGroupUser(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	groupID := r.URL.Query().Get("groupId")
+	members, ok := f.groups[groupID]
+	if !ok {
+		f.writeJSON(w, http.StatusNotFound, apiErrorBody{Code: "NOT_FOUND", Message: "group not found: " + groupID})
+		return
+	}
+	f.groups[groupID] = slices.DeleteFunc(members, func(accountID string) bool {
+		return accountID == r.URL.Query().Get("accountId")
+	})
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSetState returns a handler for the bulk archive/unarchive endpoints
+func (f *fakeAtlassianServer) handleSetState(state string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload PublicApiBulkOperationRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: err.Error()})
+			return
+		}
+		if len(payload.TeamIDs) == 0 || len(payload.TeamIDs) > maxTeamsPerBulkOperation {
+			f.writeJSON(w, http.StatusBadRequest, apiErrorBody{Code: "BAD_REQUEST", Message: "teamIds must contain 1 to 100 items"})
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		result := PublicApiBulkOperationResponse{Errors: []PublicApiBulkTeamOperationError{}, SuccessfulTeamIds: []string{}}
+		for _, teamID := range payload.TeamIDs {
+			if f.failStateChanges {
+				result.Errors = append(result.Errors, PublicApiBulkTeamOperationError{TeamID: teamID, Code: "FORBIDDEN", Message: "state change not allowed"})
+				continue
+			}
+			team, ok := f.teams[teamID]
+			if !ok {
+				result.Errors = append(result.Errors, PublicApiBulkTeamOperationError{TeamID: teamID, Code: "NOT_FOUND", Message: "team not found"})
+				continue
+			}
+			team.State = state
+			result.SuccessfulTeamIds = append(result.SuccessfulTeamIds, teamID)
+		}
+		f.writeJSON(w, http.StatusOK, result)
+	}
+}
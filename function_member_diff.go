@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MemberDiffFunction{}
+
+func NewMemberDiffFunction() function.Function {
+	return &MemberDiffFunction{}
+}
+
+// MemberDiffFunction computes the membership changes between two lists of
+// account IDs, the same way the team resource reconciles members.
+type MemberDiffFunction struct{}
+
+// memberDiffReturnAttrTypes are the attributes of the member_diff result
+var memberDiffReturnAttrTypes = map[string]attr.Type{
+	"to_add":    types.ListType{ElemType: types.StringType},
+	"to_remove": types.ListType{ElemType: types.StringType},
+}
+
+func (f *MemberDiffFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "member_diff"
+}
+
+func (f *MemberDiffFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the membership changes between two lists of account IDs",
+		MarkdownDescription: "Returns an object with `to_add`, the accounts of `desired` missing from `current`, and `to_remove`, " +
+			"the accounts of `current` missing from `desired`. This is the difference `atlassian_team` applies when reconciling `members`. " +
+			"Each account is listed once, in the order of its input list.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "current",
+				MarkdownDescription: "Account IDs of the current members",
+				ElementType:         types.StringType,
+			},
+			function.ListParameter{
+				Name:                "desired",
+				MarkdownDescription: "Account IDs of the desired members",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: memberDiffReturnAttrTypes,
+		},
+	}
+}
+
+func (f *MemberDiffFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var current, desired []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &current, &desired))
+	if resp.Error != nil {
+		return
+	}
+
+	toAdd, toRemove := diffTeamMembers(accountIDsToMembers(current), accountIDsToMembers(desired))
+
+	result, diags := types.ObjectValue(memberDiffReturnAttrTypes, map[string]attr.Value{
+		"to_add":    uniqueAccountIDsList(toAdd),
+		"to_remove": uniqueAccountIDsList(toRemove),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// uniqueAccountIDsList returns the account IDs of members as a list, keeping
+// the first occurrence of each account
+func uniqueAccountIDsList(members []TeamMember) types.List {
+	seen := make(map[string]bool, len(members))
+	elements := make([]attr.Value, 0, len(members))
+	for _, member := range members {
+		if seen[member.AccountID] {
+			continue
+		}
+		seen[member.AccountID] = true
+		elements = append(elements, types.StringValue(member.AccountID))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
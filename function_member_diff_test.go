@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testStringList(values ...string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+func TestMemberDiffFunction(t *testing.T) {
+	tests := map[string]struct {
+		current, desired []string
+		toAdd, toRemove  []string
+	}{
+		"overlapping": {[]string{"a", "b"}, []string{"b", "c"}, []string{"c"}, []string{"a"}},
+		"disjoint":    {[]string{"a"}, []string{"b", "c"}, []string{"b", "c"}, []string{"a"}},
+		"identical":   {[]string{"a", "b"}, []string{"b", "a"}, nil, nil},
+		"duplicates":  {[]string{"a", "a"}, []string{"c", "c"}, []string{"c"}, []string{"a"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(memberDiffReturnAttrTypes))}
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+				testStringList(tt.current...),
+				testStringList(tt.desired...),
+			})}
+			NewMemberDiffFunction().Run(context.Background(), req, resp)
+			if resp.Error != nil {
+				t.Fatalf("Run failed: %v", resp.Error)
+			}
+
+			want := types.ObjectValueMust(memberDiffReturnAttrTypes, map[string]attr.Value{
+				"to_add":    testStringList(tt.toAdd...),
+				"to_remove": testStringList(tt.toRemove...),
+			})
+			if got := resp.Result.Value(); !got.Equal(want) {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		})
+	}
+}
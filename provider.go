@@ -2,14 +2,27 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
 	"os"
-
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -26,14 +39,39 @@ type AtlassianProvider struct {
 	version string
 }
 
+// AtlassianProviderRetryPolicyModel describes the retry_policy block
+type AtlassianProviderRetryPolicyModel struct {
+	Retry429 types.Bool `tfsdk:"retry_429"`
+	Retry5xx types.Bool `tfsdk:"retry_5xx"`
+}
+
 // AtlassianProviderModel describes the provider data model.
 type AtlassianProviderModel struct {
-	ApiToken     types.String `tfsdk:"api_token"`
-	Email        types.String `tfsdk:"email"`
-	Organization types.String `tfsdk:"organization"`
-	SiteId       types.String `tfsdk:"site_id"`
-	OrgId        types.String `tfsdk:"org_id"`
-	BaseUrl      types.String `tfsdk:"base_url"`
+	ApiToken              types.String                       `tfsdk:"api_token"`
+	ApiTokenFile          types.String                       `tfsdk:"api_token_file"`
+	ReadApiToken          types.String                       `tfsdk:"read_api_token"`
+	WriteApiToken         types.String                       `tfsdk:"write_api_token"`
+	Email                 types.String                       `tfsdk:"email"`
+	Organization          types.String                       `tfsdk:"organization"`
+	SiteId                types.String                       `tfsdk:"site_id"`
+	RequireSiteId         types.Bool                         `tfsdk:"require_site_id"`
+	OrgId                 types.String                       `tfsdk:"org_id"`
+	BaseUrl               types.String                       `tfsdk:"base_url"`
+	Region                types.String                       `tfsdk:"region"`
+	DefaultTeamType       types.String                       `tfsdk:"default_team_type"`
+	InsecureSkipVerify    types.Bool                         `tfsdk:"insecure_skip_verify"`
+	CACertFile            types.String                       `tfsdk:"ca_cert_file"`
+	OAuthClientId         types.String                       `tfsdk:"oauth_client_id"`
+	OAuthClientSecret     types.String                       `tfsdk:"oauth_client_secret"`
+	OAuthRefreshToken     types.String                       `tfsdk:"oauth_refresh_token"`
+	ValidateCredentials   types.Bool                         `tfsdk:"validate_credentials"`
+	TreatMissingAsDeleted types.Bool                         `tfsdk:"treat_missing_as_deleted"`
+	ExposeRawJSON         types.Bool                         `tfsdk:"expose_raw_json"`
+	DefaultAcceptHeader   types.String                       `tfsdk:"default_accept_header"`
+	ExtraHeaders          types.Map                          `tfsdk:"extra_headers"`
+	TeamsAPIVersion       types.String                       `tfsdk:"teams_api_version"`
+	MaxRetryDuration      types.Int64                        `tfsdk:"max_retry_duration_seconds"`
+	RetryPolicy           *AtlassianProviderRetryPolicyModel `tfsdk:"retry_policy"`
 }
 
 func (p *AtlassianProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,24 +87,136 @@ func (p *AtlassianProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"api_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the Atlassian API token. Only used when api_token and ATLASSIAN_API_TOKEN are unset. Can also be set via ATLASSIAN_API_TOKEN_FILE environment variable.",
+				Optional:            true,
+			},
+			"read_api_token": schema.StringAttribute{
+				MarkdownDescription: "API token used by data sources, e.g. a token scoped to read teams. Defaults to api_token. Can also be set via ATLASSIAN_READ_API_TOKEN environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"write_api_token": schema.StringAttribute{
+				MarkdownDescription: "API token used by resources, which also read the objects they manage. Defaults to api_token. Can also be set via ATLASSIAN_WRITE_API_TOKEN environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "Email address associated with the Atlassian account. Can also be set via ATLASSIAN_EMAIL environment variable.",
 				Optional:            true,
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "Atlassian organization/site name. Can also be set via ATLASSIAN_ORGANIZATION environment variable.",
+				MarkdownDescription: "Atlassian organization/site name. org_id takes precedence in API paths. Can also be set via ATLASSIAN_ORGANIZATION environment variable.",
 				Optional:            true,
 			},
 			"site_id": schema.StringAttribute{
-				MarkdownDescription: "Atlassian site ID (cloudid) for API access. Required for Jira/Confluence APIs. Can also be set via ATLASSIAN_SITE_ID environment variable.",
+				MarkdownDescription: "Atlassian site ID (cloudid) for API access. Required for Jira/Confluence APIs. Team reads are scoped to it unless a resource or data source sets its own site_id. Can also be set via ATLASSIAN_SITE_ID environment variable.",
 				Optional:            true,
 			},
+			"require_site_id": schema.BoolAttribute{
+				MarkdownDescription: "Fail when no site_id is configured, and never retry a team read the site does not find without the site scope, so team reads are always scoped to a site. " +
+					"Set it where teams are only visible within a Jira or Confluence site, as unscoped reads of such teams return 404s that remove them from state. Defaults to false.",
+				Optional: true,
+			},
 			"org_id": schema.StringAttribute{
 				MarkdownDescription: "Atlassian organization ID for admin APIs. Can also be set via ATLASSIAN_ORG_ID environment variable.",
 				Optional:            true,
 			},
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "Base URL for Atlassian API. Defaults to https://api.atlassian.com. Can also be set via ATLASSIAN_BASE_URL environment variable.",
+				MarkdownDescription: "Base URL for Atlassian API. Defaults to the API host of region. Can also be set via ATLASSIAN_BASE_URL environment variable.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Atlassian cloud whose API host is used when base_url is unset: `global` for https://api.atlassian.com, " +
+					"`us-gov` for Atlassian Government Cloud at https://api.atlassian-us-gov-mod.com. Data residency locations such as the US or EU are served by the global host. " +
+					"Defaults to `global`. Can also be set via ATLASSIAN_REGION environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(slices.Sorted(maps.Keys(regionBaseURLs))...),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip TLS certificate verification. Only use this for test or staging endpoints with self-signed certificates. Defaults to false.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM bundle of CA certificates to trust in addition to the system roots, e.g. for endpoints behind a private CA.",
+				Optional:            true,
+			},
+			"oauth_client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID of an Atlassian OAuth 2.0 (3LO) app. When set together with oauth_client_secret and oauth_refresh_token, OAuth access tokens are used instead of the API token. Can also be set via ATLASSIAN_OAUTH_CLIENT_ID environment variable.",
+				Optional:            true,
+			},
+			"oauth_client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the Atlassian OAuth 2.0 (3LO) app. Can also be set via ATLASSIAN_OAUTH_CLIENT_SECRET environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"oauth_refresh_token": schema.StringAttribute{
+				MarkdownDescription: "OAuth 2.0 refresh token, exchanged for an access token when the provider is configured and again whenever the access token expires. Can also be set via ATLASSIAN_OAUTH_REFRESH_TOKEN environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"default_accept_header": schema.StringAttribute{
+				MarkdownDescription: "Accept header sent with every request instead of the per-endpoint values (`application/json`, or `*/*` for membership endpoints). Only needed behind gateways or proxies that mishandle those values.",
+				Optional:            true,
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Additional headers sent with every request, e.g. `X-ExperimentalApi = \"opt-in\"` for endpoints that require an opt-in. " +
+					"They replace the provider's default headers of the same name, but not the Accept or other headers an endpoint sets itself, nor `default_accept_header`. " +
+					"`Authorization` cannot be set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOfCaseInsensitive("Authorization")),
+				},
+			},
+			"teams_api_version": schema.StringAttribute{
+				MarkdownDescription: "Version segment of the Teams API paths (`/public/teams/<version>/...`), so a new API version can be used without a provider release. Defaults to `v1`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^v[0-9]+$`), "must be an API version such as v1"),
+				},
+			},
+			"max_retry_duration_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Total time in seconds the provider may spend waiting between retries of throttled or unavailable requests, across all requests of a run. " +
+					"Once it is used up, failing requests are not retried, so a broad outage fails fast. `0` disables retries. Defaults to no limit.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_policy": schema.SingleNestedAttribute{
+				MarkdownDescription: "Which failed responses are retried. Throttled (429) responses wait as long as their Retry-After header asks, " +
+					"server errors (502, 503, 504) back off exponentially. Network errors are retried regardless, except for POST requests.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"retry_429": schema.BoolAttribute{
+						MarkdownDescription: "Retry throttled (429) responses. Defaults to true.",
+						Optional:            true,
+					},
+					"retry_5xx": schema.BoolAttribute{
+						MarkdownDescription: "Retry 502, 503 and 504 responses. A 502 or 504 may come after the API already acted, so disable this if repeated writes are a concern; POST requests are never retried on them. Defaults to true.",
+						Optional:            true,
+					},
+				},
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Check the credentials and org_id with a single lightweight API call when the provider is configured, so misconfiguration fails early with a precise error. Defaults to true.",
+				Optional:            true,
+			},
+			"expose_raw_json": schema.BoolAttribute{
+				MarkdownDescription: "Record the raw JSON of the last team response in the `raw_json` attribute of `atlassian_team` resources and data sources, to help report API changes. " +
+					"Off by default to keep state small. Defaults to false.",
+				Optional: true,
+			},
+			"treat_missing_as_deleted": schema.BoolAttribute{
+				MarkdownDescription: "Remove teams and group memberships from state when a refresh gets a 404, assuming they were deleted outside Terraform. " +
+					"Set it to false where a 404 may be transient, e.g. due to eventual consistency or a wrong site_id, so the refresh fails instead and state is kept for investigation. Defaults to true.",
+				Optional: true,
+			},
+			"default_team_type": schema.StringAttribute{
+				MarkdownDescription: "Team type used by atlassian_team resources that do not set team_type (OPEN, MEMBER_INVITE, EXTERNAL, ORG_ADMIN_MANAGED).",
 				Optional:            true,
 			},
 		},
@@ -93,6 +243,23 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if data.ApiTokenFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_token_file"),
+			"Unknown Atlassian API Token File",
+			"The provider cannot create the Atlassian API client as there is an unknown configuration value for the Atlassian API token file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ATLASSIAN_API_TOKEN_FILE environment variable.",
+		)
+	}
+
+	if data.ReadApiToken.IsUnknown() || data.WriteApiToken.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Atlassian Scoped API Token",
+			"The provider cannot create the Atlassian API client as there is an unknown configuration value for read_api_token or write_api_token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ATLASSIAN_READ_API_TOKEN and ATLASSIAN_WRITE_API_TOKEN environment variables.",
+		)
+	}
+
 	if data.Email.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("email"),
@@ -129,6 +296,14 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if data.OAuthClientId.IsUnknown() || data.OAuthClientSecret.IsUnknown() || data.OAuthRefreshToken.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Atlassian OAuth Credentials",
+			"The provider cannot create the Atlassian API client as there is an unknown configuration value for the Atlassian OAuth credentials. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ATLASSIAN_OAUTH_* environment variables.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -137,16 +312,35 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 	// with Terraform configuration value if set.
 
 	apiToken := os.Getenv("ATLASSIAN_API_TOKEN")
+	apiTokenFile := os.Getenv("ATLASSIAN_API_TOKEN_FILE")
+	readApiToken := os.Getenv("ATLASSIAN_READ_API_TOKEN")
+	writeApiToken := os.Getenv("ATLASSIAN_WRITE_API_TOKEN")
 	email := os.Getenv("ATLASSIAN_EMAIL")
 	organization := os.Getenv("ATLASSIAN_ORGANIZATION")
 	siteId := os.Getenv("ATLASSIAN_SITE_ID")
 	orgId := os.Getenv("ATLASSIAN_ORG_ID")
 	baseUrl := os.Getenv("ATLASSIAN_BASE_URL")
+	region := os.Getenv("ATLASSIAN_REGION")
+	oauthClientId := os.Getenv("ATLASSIAN_OAUTH_CLIENT_ID")
+	oauthClientSecret := os.Getenv("ATLASSIAN_OAUTH_CLIENT_SECRET")
+	oauthRefreshToken := os.Getenv("ATLASSIAN_OAUTH_REFRESH_TOKEN")
 
 	if !data.ApiToken.IsNull() {
 		apiToken = data.ApiToken.ValueString()
 	}
 
+	if !data.ApiTokenFile.IsNull() {
+		apiTokenFile = data.ApiTokenFile.ValueString()
+	}
+
+	if !data.ReadApiToken.IsNull() {
+		readApiToken = data.ReadApiToken.ValueString()
+	}
+
+	if !data.WriteApiToken.IsNull() {
+		writeApiToken = data.WriteApiToken.ValueString()
+	}
+
 	if !data.Email.IsNull() {
 		email = data.Email.ValueString()
 	}
@@ -162,21 +356,71 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 	if !data.OrgId.IsNull() {
 		orgId = data.OrgId.ValueString()
 	}
+	orgId = strings.TrimSpace(orgId)
 
 	if !data.BaseUrl.IsNull() {
 		baseUrl = data.BaseUrl.ValueString()
 	}
 
+	if !data.Region.IsNull() {
+		region = data.Region.ValueString()
+	}
+
+	if !data.OAuthClientId.IsNull() {
+		oauthClientId = data.OAuthClientId.ValueString()
+	}
+
+	if !data.OAuthClientSecret.IsNull() {
+		oauthClientSecret = data.OAuthClientSecret.ValueString()
+	}
+
+	if !data.OAuthRefreshToken.IsNull() {
+		oauthRefreshToken = data.OAuthRefreshToken.ValueString()
+	}
+	useOAuth := oauthClientId != "" || oauthClientSecret != "" || oauthRefreshToken != ""
+
+	// The token file is only consulted when no token was given directly
+	if apiToken == "" && apiTokenFile != "" {
+		token, err := readAPITokenFile(apiTokenFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_token_file"),
+				"Unable to Read Atlassian API Token File",
+				"The provider cannot create the Atlassian API client as the Atlassian API token file could not be read: "+err.Error(),
+			)
+			return
+		}
+		apiToken = token
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if apiToken == "" {
+	if useOAuth && (oauthClientId == "" || oauthClientSecret == "" || oauthRefreshToken == "") {
+		resp.Diagnostics.AddError(
+			"Incomplete Atlassian OAuth Credentials",
+			"The provider cannot create the Atlassian API client as only some of the OAuth credentials are set. "+
+				"Set all of oauth_client_id, oauth_client_secret and oauth_refresh_token (or the matching ATLASSIAN_OAUTH_* environment variables), or none of them to use an API token.",
+		)
+	}
+
+	// The scoped tokens fall back to api_token, which is only required when one of them is unset
+	if readApiToken == "" {
+		readApiToken = apiToken
+	}
+	if writeApiToken == "" {
+		writeApiToken = apiToken
+	}
+
+	if (readApiToken == "" || writeApiToken == "") && !useOAuth {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_token"),
 			"Missing Atlassian API Token",
 			"The provider cannot create the Atlassian API client as there is a missing or empty value for the Atlassian API token. "+
-				"Set the api_token value in the configuration or use the ATLASSIAN_API_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty. For Teams API, use an Atlassian Admin API token.",
+				"Set the api_token value in the configuration or use the ATLASSIAN_API_TOKEN environment variable, "+
+				"or point api_token_file or ATLASSIAN_API_TOKEN_FILE at a file containing the token. "+
+				"If either is already set, ensure the value is not empty. For Teams API, use an Atlassian Admin API token. "+
+				"When only one of read_api_token and write_api_token is set, api_token is still needed for the other.",
 		)
 	}
 
@@ -190,6 +434,17 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if orgId != "" {
+		if err := validateOrgId(orgId); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("org_id"),
+				"Invalid Atlassian Organization ID",
+				"The provider cannot create the Atlassian API client as the Atlassian organization ID is invalid: "+err.Error()+". "+
+					"Set org_id to the bare organization ID (e.g. 12345678-1234-1234-1234-123456789012), not a URL or path.",
+			)
+		}
+	}
+
 	// Email and organization are now optional - keep for backward compatibility
 	// but warn if org_id is missing
 	if organization == "" && orgId == "" {
@@ -201,8 +456,41 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if conflictingOrganization(organization, orgId) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("organization"),
+			"Conflicting Organization Identifiers",
+			fmt.Sprintf("organization is set to the organization ID %s, but org_id is %s. The provider uses org_id for all API paths and ignores organization. "+
+				"Remove organization, or correct whichever of the two is wrong.", organization, orgId),
+		)
+	}
+
+	// An explicit base_url takes precedence over the region's host
+	baseUrl = strings.TrimRight(baseUrl, "/")
 	if baseUrl == "" {
-		baseUrl = "https://api.atlassian.com"
+		regionURL, err := regionBaseURL(region)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("region"), "Invalid Atlassian Region", err.Error())
+		}
+		baseUrl = regionURL
+	}
+
+	defaultTeamType := data.DefaultTeamType.ValueString()
+	if defaultTeamType != "" && !slices.Contains(teamTypes, defaultTeamType) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_team_type"),
+			"Invalid Default Team Type",
+			fmt.Sprintf("default_team_type must be one of %s, got: %s", strings.Join(teamTypes, ", "), defaultTeamType),
+		)
+	}
+
+	if data.RequireSiteId.ValueBool() && siteId == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("site_id"),
+			"Missing Atlassian Site ID",
+			"The provider cannot create the Atlassian API client as require_site_id is set but there is a missing or empty value for the Atlassian site ID. "+
+				"Set the site_id value in the configuration or use the ATLASSIAN_SITE_ID environment variable, or unset require_site_id.",
+		)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -210,7 +498,7 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	// Create a new Atlassian client using the configuration values
-	client, err := NewAtlassianClient(apiToken, email, organization, siteId, orgId, baseUrl)
+	client, err := NewAtlassianClient(writeApiToken, email, organization, siteId, orgId, baseUrl)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Atlassian API Client",
@@ -220,30 +508,196 @@ func (p *AtlassianProvider) Configure(ctx context.Context, req provider.Configur
 		)
 		return
 	}
+	client.DefaultTeamType = defaultTeamType
+	client.RequireSiteId = data.RequireSiteId.ValueBool()
+	client.DefaultAcceptHeader = data.DefaultAcceptHeader.ValueString()
+	if !data.TreatMissingAsDeleted.IsNull() {
+		client.TreatMissingAsDeleted = data.TreatMissingAsDeleted.ValueBool()
+	}
+	client.ExposeRawJSON = data.ExposeRawJSON.ValueBool()
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &client.ExtraHeaders, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.MaxRetryDuration.IsNull() {
+		client.SetMaxRetryDuration(time.Duration(data.MaxRetryDuration.ValueInt64()) * time.Second)
+	}
+	if data.RetryPolicy != nil {
+		if !data.RetryPolicy.Retry429.IsNull() {
+			client.RetryPolicies[RetryClassThrottled] = RetryPolicy{Enabled: data.RetryPolicy.Retry429.ValueBool(), HonorRetryAfter: true}
+		}
+		if !data.RetryPolicy.Retry5xx.IsNull() {
+			client.RetryPolicies[RetryClassServerError] = RetryPolicy{Enabled: data.RetryPolicy.Retry5xx.ValueBool()}
+		}
+	}
+	if teamsAPIVersion := data.TeamsAPIVersion.ValueString(); teamsAPIVersion != "" {
+		client.TeamsAPIVersion = teamsAPIVersion
+	}
+
+	if caCertFile := data.CACertFile.ValueString(); caCertFile != "" {
+		if err := client.SetCACertFile(caCertFile); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid CA Certificate File",
+				"The provider cannot create the Atlassian API client as the CA certificate file could not be loaded: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if data.InsecureSkipVerify.ValueBool() {
+		client.SetInsecureSkipVerify(true)
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is enabled, so the provider does not verify the TLS certificate of "+baseUrl+". "+
+				"This exposes the API token to man-in-the-middle attacks and must not be used in production.",
+		)
+	}
+
+	if useOAuth {
+		if err := client.SetOAuthCredentials(ctx, oauthClientId, oauthClientSecret, oauthRefreshToken); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oauth_refresh_token"),
+				"Unable to Obtain Atlassian OAuth Access Token",
+				"The provider cannot create the Atlassian API client as the OAuth refresh token could not be exchanged for an access token: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Data sources read with their own token when one is set, the copy shares
+	// the transport and retry budget configured above
+	readClient := client.WithAPIToken(readApiToken)
+
+	if data.ValidateCredentials.IsNull() || data.ValidateCredentials.ValueBool() {
+		if err := client.WithContext(ctx).Ping(); err != nil {
+			addCredentialsError(&resp.Diagnostics, err)
+			return
+		}
+		if readClient != client {
+			if err := readClient.WithContext(ctx).Ping(); err != nil {
+				addCredentialsError(&resp.Diagnostics, fmt.Errorf("read_api_token: %w", err))
+				return
+			}
+		}
+	}
 
 	// Make the Atlassian client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
+	resp.DataSourceData = readClient
 	resp.ResourceData = client
 
 	tflog.Info(ctx, "Configured Atlassian client", map[string]any{"success": true})
 }
 
+// addCredentialsError reports a failed Ping as a diagnostic on the attribute most likely at fault
+func addCredentialsError(diags *diag.Diagnostics, err error) {
+	const hint = "\n\nSet validate_credentials = false to skip this check."
+
+	switch {
+	case errors.Is(err, ErrAuthenticationFailed):
+		diags.AddAttributeError(
+			path.Root("api_token"),
+			"Atlassian Authentication Failed",
+			"The Atlassian API rejected the configured credentials. Check that the API token (or OAuth credentials) is valid, "+
+				"not expired, and is an Atlassian Admin API token.\n\n"+err.Error()+hint,
+		)
+	case errors.Is(err, ErrPermissionDenied):
+		diags.AddAttributeError(
+			path.Root("api_token"),
+			"Insufficient Atlassian Permissions",
+			"The configured credentials are valid but may not manage teams of this organization. "+
+				"Check that the token has the read:team:atlassian and write:team:atlassian scopes.\n\n"+err.Error()+hint,
+		)
+	case errors.Is(err, ErrOrgNotFound):
+		diags.AddAttributeError(
+			path.Root("org_id"),
+			"Atlassian Organization Not Found",
+			"The Atlassian organization could not be found. Check that org_id is the organization ID shown in Atlassian Admin.\n\n"+err.Error()+hint,
+		)
+	default:
+		diags.AddError(
+			"Unable to Validate Atlassian Credentials",
+			"The provider could not reach the Atlassian API to validate its configuration: "+err.Error()+hint,
+		)
+	}
+}
+
+// regionBaseURLs maps the region attribute to the API host of that cloud
+var regionBaseURLs = map[string]string{
+	"global": "https://api.atlassian.com",
+	"us-gov": "https://api.atlassian-us-gov-mod.com",
+}
+
+// regionBaseURL returns the API host of region, the global host if it is empty
+func regionBaseURL(region string) (string, error) {
+	if region == "" {
+		region = "global"
+	}
+	baseURL, ok := regionBaseURLs[region]
+	if !ok {
+		return regionBaseURLs["global"], fmt.Errorf("region must be one of %s, got: %s", strings.Join(slices.Sorted(maps.Keys(regionBaseURLs)), ", "), region)
+	}
+	return baseURL, nil
+}
+
+// orgIdPattern matches the UUID form of Atlassian organization IDs
+var orgIdPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// conflictingOrganization reports whether the legacy organization is set to
+// an organization ID other than orgId. An organization name next to org_id is
+// the usual configuration and not a conflict.
+func conflictingOrganization(organization, orgId string) bool {
+	return orgId != "" && orgIdPattern.MatchString(organization) && !strings.EqualFold(organization, orgId)
+}
+
+// readAPITokenFile reads an API token from a file, trimming trailing newlines
+func readAPITokenFile(filename string) (string, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// validateOrgId rejects org IDs that would produce a malformed API path
+func validateOrgId(orgId string) error {
+	if strings.Contains(orgId, "/") {
+		return fmt.Errorf("%q must not contain '/'", orgId)
+	}
+	if strings.IndexFunc(orgId, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%q must not contain whitespace", orgId)
+	}
+	return nil
+}
+
 func (p *AtlassianProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewTeamResource,
+		NewGroupMembershipResource,
+		NewTeamBulkArchiveResource,
+		NewTeamBulkDescriptionResource,
+		NewTeamBulkDeleteResource,
 	}
 }
 
 func (p *AtlassianProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// Add data sources here if needed
+		NewTeamDataSource,
+		NewTeamsDataSource,
+		NewTeamMembershipSummaryDataSource,
+		NewTeamMemberDataSource,
+		NewOrganizationDataSource,
+		NewWhoAmIDataSource,
 	}
 }
 
 func (p *AtlassianProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// Add functions here if needed
+		NewMemberDiffFunction,
 	}
 }
 
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -39,3 +46,143 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("Provider factory should not return error: %v", err)
 	}
 }
+
+func TestValidateOrgId(t *testing.T) {
+	if err := validateOrgId("12345678-1234-1234-1234-123456789012"); err != nil {
+		t.Errorf("Expected UUID-style org ID to be valid, got: %v", err)
+	}
+
+	invalid := []string{
+		"https://admin.atlassian.com/o/12345678",
+		"org/12345678",
+		"1234 5678",
+		"1234\t5678",
+	}
+	for _, orgId := range invalid {
+		t.Run(orgId, func(t *testing.T) {
+			if err := validateOrgId(orgId); err == nil {
+				t.Errorf("Expected %q to be rejected", orgId)
+			}
+		})
+	}
+}
+
+func TestReadAPITokenFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(filename, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatalf("Unable to write token file: %v", err)
+	}
+
+	token, err := readAPITokenFile(filename)
+	if err != nil {
+		t.Fatalf("Expected token file to be readable, got: %v", err)
+	}
+	if token != "secret-token" {
+		t.Errorf("Expected trailing newline to be trimmed, got %q", token)
+	}
+
+	if _, err := readAPITokenFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error for a missing token file")
+	}
+}
+
+func TestRegionBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"":       "https://api.atlassian.com",
+		"global": "https://api.atlassian.com",
+		"us-gov": "https://api.atlassian-us-gov-mod.com",
+	}
+	for region, want := range tests {
+		if got, err := regionBaseURL(region); err != nil || got != want {
+			t.Errorf("region %q: expected %s, got %s, %v", region, want, got, err)
+		}
+	}
+
+	if _, err := regionBaseURL("mars"); err == nil {
+		t.Error("Expected an unknown region to be rejected")
+	}
+}
+
+func TestConflictingOrganization(t *testing.T) {
+	const orgId = "12345678-1234-1234-1234-123456789012"
+	tests := map[string]bool{
+		"":                                     false,
+		"mycompany":                            false,
+		orgId:                                  false,
+		"87654321-4321-4321-4321-210987654321": true,
+	}
+	for organization, want := range tests {
+		if got := conflictingOrganization(organization, orgId); got != want {
+			t.Errorf("organization %q: expected %v, got %v", organization, want, got)
+		}
+	}
+}
+
+// configureTestProvider configures the provider with attrs, leaving all other
+// attributes unset
+func configureTestProvider(t *testing.T, attrs map[string]any) *provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	p := NewProvider("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	config := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attrs {
+		if diags := config.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("Unable to set %s: %v", name, diags)
+		}
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+	return resp
+}
+
+func TestProviderRequireSiteId(t *testing.T) {
+	t.Setenv("ATLASSIAN_SITE_ID", "")
+	attrs := map[string]any{
+		"api_token":            "token",
+		"org_id":               "12345678-1234-1234-1234-123456789012",
+		"validate_credentials": false,
+		"require_site_id":      true,
+	}
+
+	if resp := configureTestProvider(t, attrs); !resp.Diagnostics.HasError() {
+		t.Error("Expected a missing site_id to fail when require_site_id is set")
+	}
+
+	attrs["site_id"] = "site-1"
+	resp := configureTestProvider(t, attrs)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure failed: %v", resp.Diagnostics)
+	}
+	if client, ok := resp.ResourceData.(*AtlassianClient); !ok || client.SiteId != "site-1" || !client.RequireSiteId {
+		t.Errorf("Expected a client requiring site-1, got %+v", resp.ResourceData)
+	}
+}
+
+func TestProviderValidatesDefaultTeamType(t *testing.T) {
+	attrs := map[string]any{
+		"api_token":            "token",
+		"org_id":               "12345678-1234-1234-1234-123456789012",
+		"validate_credentials": false,
+		"default_team_type":    "SECRET",
+	}
+	if resp := configureTestProvider(t, attrs); !resp.Diagnostics.HasError() {
+		t.Error("Expected an unknown default_team_type to be rejected")
+	}
+
+	attrs["default_team_type"] = "MEMBER_INVITE"
+	resp := configureTestProvider(t, attrs)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure failed: %v", resp.Diagnostics)
+	}
+	if client := resp.ResourceData.(*AtlassianClient); client.DefaultTeamType != "MEMBER_INVITE" {
+		t.Errorf("Expected default team type MEMBER_INVITE, got %q", client.DefaultTeamType)
+	}
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupMembershipResource{}
+var _ resource.ResourceWithImportState = &GroupMembershipResource{}
+
+func NewGroupMembershipResource() resource.Resource {
+	return &GroupMembershipResource{}
+}
+
+// GroupMembershipResource defines the resource implementation.
+type GroupMembershipResource struct {
+	client *AtlassianClient
+}
+
+// GroupMembershipResourceModel describes the resource data model.
+type GroupMembershipResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	GroupID           types.String `tfsdk:"group_id"`
+	SiteId            types.String `tfsdk:"site_id"`
+	Members           types.Set    `tfsdk:"members"`
+	FailOnMemberError types.Bool   `tfsdk:"fail_on_member_error"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+}
+
+func (r *GroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_membership"
+}
+
+func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the members of a Jira/Confluence group through the Jira groups API of a site. " +
+			"Only the accounts in `members` are managed, other members of the group are left alone unless `authoritative` is set. " +
+			"The groups API takes one account per call, so large changes cost one request per member.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Group identifier, same as `group_id`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "Site identifier (cloudid) of the group. Defaults to the provider site_id.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "Account IDs of the group members managed by this resource",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(accountIdValidator{}),
+				},
+			},
+			"fail_on_member_error": schema.BoolAttribute{
+				MarkdownDescription: "Report accounts the API refuses to add or remove as errors instead of warnings. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: "Make `members` the complete membership of the group: accounts in the group but not in `members` are removed, " +
+					"and destroying the resource removes every member of the group. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *GroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	siteId := r.siteId(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The group already exists, reconcile against its current members
+	current, err := r.client.WithContext(ctx).GetGroupMembers(siteId, data.GroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group members, got error: %s", err)+permissionHint(err))
+		return
+	}
+	if !data.Authoritative.ValueBool() {
+		// Unlisted members are not managed, so they are never removed
+		current = r.managedMembers(ctx, data.Members, current, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.ID = data.GroupID
+	r.applyGroupMembers(ctx, siteId, &data, current, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "created a group membership resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	siteId := r.siteId(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := r.client.WithContext(ctx).GetGroupMembers(siteId, data.GroupID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) && r.client.TreatMissingAsDeleted {
+			// Group was deleted outside Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group members, got error: %s", err)+missingHint(err)+permissionHint(err))
+		return
+	}
+
+	// Provider-only settings are not returned by the API, default them after import
+	if data.FailOnMemberError.IsNull() {
+		data.FailOnMemberError = types.BoolValue(false)
+	}
+	if data.Authoritative.IsNull() {
+		data.Authoritative = types.BoolValue(false)
+	}
+
+	// After import every member is taken over, otherwise only the managed ones
+	// that are still in the group are kept
+	if !data.Authoritative.ValueBool() && !data.Members.IsNull() {
+		members = r.managedMembers(ctx, data.Members, members, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	data.Members = accountIDsToSet(members)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior GroupMembershipResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	siteId := r.siteId(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var current []string
+	resp.Diagnostics.Append(prior.Members.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyGroupMembers(ctx, siteId, &data, current, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "updated a group membership resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	siteId := r.siteId(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The group itself is not deleted, only the managed members are removed.
+	// State only holds all members of the group if the resource is authoritative.
+	memberErrors, err := r.client.WithContext(ctx).RemoveGroupMembers(siteId, data.GroupID.ValueString(), members)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove group members, got error: %s", err)+permissionHint(err))
+		return
+	}
+	for _, e := range memberErrors {
+		resp.Diagnostics.AddError("Group Member Not Removed", fmt.Sprintf("Account %s: %s - %s", e.AccountID, e.Code, e.Message))
+	}
+
+	tflog.Trace(ctx, "deleted a group membership resource")
+}
+
+func (r *GroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by group ID, using the provider site_id
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), req.ID)...)
+}
+
+// siteId returns the site of the group, defaulting to the provider site_id
+func (r *GroupMembershipResource) siteId(data *GroupMembershipResourceModel, diags *diag.Diagnostics) string {
+	if !data.SiteId.IsNull() && data.SiteId.ValueString() != "" {
+		return data.SiteId.ValueString()
+	}
+	if r.client.SiteId == "" {
+		diags.AddAttributeError(
+			path.Root("site_id"),
+			"Missing Site ID",
+			"Groups belong to a site, so site_id must be set on the resource or the provider.",
+		)
+	}
+	return r.client.SiteId
+}
+
+// managedMembers returns the accounts of groupMembers that are also in managed
+func (r *GroupMembershipResource) managedMembers(ctx context.Context, managed types.Set, groupMembers []string, diags *diag.Diagnostics) []string {
+	var accountIDs []string
+	diags.Append(managed.ElementsAs(ctx, &accountIDs, false)...)
+
+	members := make([]string, 0, len(accountIDs))
+	for _, accountID := range groupMembers {
+		if slices.Contains(accountIDs, accountID) {
+			members = append(members, accountID)
+		}
+	}
+	return members
+}
+
+// applyGroupMembers adds and removes accounts so the managed members in
+// current match the planned members, and records the managed members the
+// group has afterwards in data. Accounts a failed batch did get to are
+// recorded as well.
+func (r *GroupMembershipResource) applyGroupMembers(ctx context.Context, siteId string, data *GroupMembershipResourceModel, current []string, diags *diag.Diagnostics) {
+	var desired []string
+	diags.Append(data.Members.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	toAdd, toRemove := diffTeamMembers(accountIDsToMembers(current), accountIDsToMembers(desired))
+	client := r.client.WithContext(ctx)
+	groupID := data.GroupID.ValueString()
+
+	failed := make(map[string]bool)
+	reportErrors := func(memberErrors []PublicApiMembershipCodedError) {
+		for _, e := range memberErrors {
+			failed[e.AccountID] = true
+			if e.Code == groupMemberNotAttempted {
+				// Covered by the client error that stopped the batch
+				continue
+			}
+
+			summary := "Group Member Not Updated"
+			detail := fmt.Sprintf("Account %s: %s - %s", e.AccountID, e.Code, e.Message)
+			if data.FailOnMemberError.ValueBool() {
+				diags.AddAttributeError(path.Root("members"), summary, detail)
+			} else {
+				diags.AddAttributeWarning(path.Root("members"), summary, detail)
+			}
+		}
+	}
+
+	if len(toAdd) > 0 {
+		memberErrors, err := client.AddGroupMembers(siteId, groupID, membersToAccountIDs(toAdd))
+		reportErrors(memberErrors)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to add group members, got error: %s", err)+permissionHint(err))
+		}
+	}
+
+	removed := make(map[string]bool)
+	if len(toRemove) > 0 && !diags.HasError() {
+		memberErrors, err := client.RemoveGroupMembers(siteId, groupID, membersToAccountIDs(toRemove))
+		reportErrors(memberErrors)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove group members, got error: %s", err)+permissionHint(err))
+		}
+		for _, member := range toRemove {
+			removed[member.AccountID] = !failed[member.AccountID]
+		}
+	}
+
+	applied := make([]string, 0, len(desired))
+	for _, accountID := range current {
+		if !removed[accountID] {
+			applied = append(applied, accountID)
+		}
+	}
+	for _, member := range toAdd {
+		if !failed[member.AccountID] {
+			applied = append(applied, member.AccountID)
+		}
+	}
+	data.Members = accountIDsToSet(applied)
+}
+
+// accountIDsToSet converts account IDs into a set of strings
+func accountIDsToSet(accountIDs []string) types.Set {
+	elements := make([]attr.Value, len(accountIDs))
+	for i, accountID := range accountIDs {
+		elements[i] = types.StringValue(accountID)
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// accountIDsToMembers converts account IDs into API members
+func accountIDsToMembers(accountIDs []string) []TeamMember {
+	members := make([]TeamMember, len(accountIDs))
+	for i, accountID := range accountIDs {
+		members[i] = TeamMember{AccountID: accountID}
+	}
+	return members
+}
+
+// membersToAccountIDs returns the account IDs of API members
+func membersToAccountIDs(members []TeamMember) []string {
+	accountIDs := make([]string, len(members))
+	for i, member := range members {
+		accountIDs[i] = member.AccountID
+	}
+	return accountIDs
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// emptyGroupMembershipState returns a null state of the group membership resource
+func emptyGroupMembershipState(r *GroupMembershipResource) tfsdk.State {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+// testGroupMembershipModel returns a planned model managing accountIDs in group-1
+func testGroupMembershipModel(accountIDs ...string) GroupMembershipResourceModel {
+	return GroupMembershipResourceModel{
+		ID:                types.StringUnknown(),
+		GroupID:           types.StringValue("group-1"),
+		SiteId:            types.StringValue("site-1"),
+		Members:           accountIDsToSet(accountIDs),
+		FailOnMemberError: types.BoolValue(false),
+		Authoritative:     types.BoolValue(false),
+	}
+}
+
+func TestGroupMembershipResourceLifecycle(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	fake.setGroupMembers("group-1", "a", "extra")
+	fake.rejectAccount("bad")
+	r := &GroupMembershipResource{client: client}
+	ctx := context.Background()
+
+	plan := emptyGroupMembershipState(r)
+	if diags := plan.Set(ctx, testGroupMembershipModel("a", "b", "bad")); diags.HasError() {
+		t.Fatalf("Unable to build plan: %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: emptyGroupMembershipState(r)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() || createResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected Create to succeed with a warning for the rejected member, got: %v", createResp.Diagnostics)
+	}
+
+	var created GroupMembershipResourceModel
+	createResp.State.Get(ctx, &created)
+	if want := accountIDsToSet([]string{"a", "b"}); !created.Members.Equal(want) {
+		t.Errorf("Expected members %v in state, got %v", want, created.Members)
+	}
+	if got := fake.groupMembers("group-1"); !slices.Equal(got, []string{"a", "extra", "b"}) {
+		t.Errorf("Expected the unmanaged member to be kept, got %v", got)
+	}
+
+	// Refreshing keeps tracking only the managed members
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	var read GroupMembershipResourceModel
+	readResp.State.Get(ctx, &read)
+	if want := accountIDsToSet([]string{"a", "b"}); readResp.Diagnostics.HasError() || !read.Members.Equal(want) {
+		t.Errorf("Expected members %v after refresh, got %v (%v)", want, read.Members, readResp.Diagnostics)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete failed: %v", deleteResp.Diagnostics)
+	}
+	if got := fake.groupMembers("group-1"); !slices.Equal(got, []string{"extra"}) {
+		t.Errorf("Expected only the managed members to be removed, got %v", got)
+	}
+
+	readResp = &resource.ReadResponse{State: createResp.State}
+	fake.mu.Lock()
+	delete(fake.groups, "group-1")
+	fake.mu.Unlock()
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("Expected Read of a deleted group to remove it from state, got %v", readResp.Diagnostics)
+	}
+}
+
+func TestGroupMembershipResourceAuthoritative(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	fake.setGroupMembers("group-1", "a", "extra")
+	r := &GroupMembershipResource{client: client}
+	ctx := context.Background()
+
+	planned := testGroupMembershipModel("a", "b")
+	planned.Authoritative = types.BoolValue(true)
+	plan := emptyGroupMembershipState(r)
+	if diags := plan.Set(ctx, planned); diags.HasError() {
+		t.Fatalf("Unable to build plan: %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: emptyGroupMembershipState(r)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", createResp.Diagnostics)
+	}
+	if got := fake.groupMembers("group-1"); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected the unlisted member to be removed, got %v", got)
+	}
+
+	// Members added outside Terraform show up as drift
+	fake.setGroupMembers("group-1", "a", "b", "outside")
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	var read GroupMembershipResourceModel
+	readResp.State.Get(ctx, &read)
+	if want := accountIDsToSet([]string{"a", "b", "outside"}); !read.Members.Equal(want) {
+		t.Errorf("Expected the full membership %v after refresh, got %v", want, read.Members)
+	}
+}
+
+func TestGroupMembershipResourceRecordsPartialBatch(t *testing.T) {
+	client, transport, _ := newScriptedClient(t,
+		scriptedResponse{status: http.StatusOK, body: `{"isLast":true,"values":[{"accountId":"a"}]}`},
+		scriptedResponse{status: http.StatusCreated, body: `{}`},
+		scriptedResponse{err: errors.New("connection reset")},
+	)
+	r := &GroupMembershipResource{client: client}
+	ctx := context.Background()
+
+	plan := emptyGroupMembershipState(r)
+	if diags := plan.Set(ctx, testGroupMembershipModel("a", "b", "c", "d")); diags.HasError() {
+		t.Fatalf("Unable to build plan: %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: emptyGroupMembershipState(r)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.ErrorsCount() != 1 || createResp.Diagnostics.WarningsCount() != 0 {
+		t.Fatalf("Expected only the client error, got: %v", createResp.Diagnostics)
+	}
+	if len(transport.requests) != 3 {
+		t.Errorf("Expected the batch to stop at the failed request, got %d requests", len(transport.requests))
+	}
+
+	// The account added before the failure is kept in state
+	var created GroupMembershipResourceModel
+	createResp.State.Get(ctx, &created)
+	if want := accountIDsToSet([]string{"a", "b"}); !created.Members.Equal(want) {
+		t.Errorf("Expected members %v in state, got %v", want, created.Members)
+	}
+}
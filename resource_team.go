@@ -2,23 +2,43 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TeamResource{}
 var _ resource.ResourceWithImportState = &TeamResource{}
+var _ resource.ResourceWithValidateConfig = &TeamResource{}
+var _ resource.ResourceWithConfigValidators = &TeamResource{}
+var _ resource.ResourceWithModifyPlan = &TeamResource{}
+var _ resource.ResourceWithUpgradeState = &TeamResource{}
+
+// teamTypes lists the team types accepted by the Teams API
+var teamTypes = []string{"OPEN", "MEMBER_INVITE", "EXTERNAL", "ORG_ADMIN_MANAGED"}
 
 func NewTeamResource() resource.Resource {
 	return &TeamResource{}
@@ -31,20 +51,35 @@ type TeamResource struct {
 
 // TeamResourceModel describes the resource data model.
 type TeamResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	DisplayName    types.String `tfsdk:"display_name"`
-	Description    types.String `tfsdk:"description"`
-	TeamType       types.String `tfsdk:"team_type"`
-	SiteId         types.String `tfsdk:"site_id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	CreatorId      types.String `tfsdk:"creator_id"`
-	State          types.String `tfsdk:"state"`
-	Members        types.Set    `tfsdk:"members"`
+	ID                     types.String `tfsdk:"id"`
+	DisplayName            types.String `tfsdk:"display_name"`
+	Description            types.String `tfsdk:"description"`
+	TeamType               types.String `tfsdk:"team_type"`
+	SiteId                 types.String `tfsdk:"site_id"`
+	OrganizationId         types.String `tfsdk:"organization_id"`
+	CreatorId              types.String `tfsdk:"creator_id"`
+	State                  types.String `tfsdk:"state"`
+	Members                types.Set    `tfsdk:"members"`
+	ManageAllMembers       types.Bool   `tfsdk:"manage_all_members"`
+	ForceDelete            types.Bool   `tfsdk:"force_delete"`
+	RetryDeleteConflicts   types.Bool   `tfsdk:"retry_delete_conflicts"`
+	AutoUnarchive          types.Bool   `tfsdk:"auto_unarchive"`
+	FailOnMemberError      types.Bool   `tfsdk:"fail_on_member_error"`
+	AdoptExisting          types.Bool   `tfsdk:"adopt_existing"`
+	ResolveMemberEmails    types.Bool   `tfsdk:"resolve_member_emails"`
+	DeletionPolicy         types.String `tfsdk:"deletion_policy"`
+	ExternalReference      types.String `tfsdk:"external_reference"`
+	SkipDestroy            types.Bool   `tfsdk:"skip_destroy"`
+	Labels                 types.Map    `tfsdk:"labels"`
+	ExpectedOrganizationId types.String `tfsdk:"expected_organization_id"`
+	RawJSON                types.String `tfsdk:"raw_json"`
 }
 
 // TeamMemberModel describes a team member data model.
 type TeamMemberModel struct {
-	AccountID types.String `tfsdk:"account_id"`
+	AccountID  types.String `tfsdk:"account_id"`
+	Email      types.String `tfsdk:"email"`
+	MemberType types.String `tfsdk:"member_type"`
 }
 
 func (r *TeamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +90,7 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Team resource for managing Atlassian teams.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -65,18 +101,33 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"display_name": schema.StringAttribute{
-				MarkdownDescription: "Team display name",
+				MarkdownDescription: "Team display name. It must contain at least one non-whitespace character.",
 				Required:            true,
+				Validators: []validator.String{
+					// Matches the API's displayName pattern .*\S+.*
+					stringvalidator.RegexMatches(regexp.MustCompile(`\S`), "must contain at least one non-whitespace character"),
+				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Team description",
-				Required:            true,
+				MarkdownDescription: "Team description. Removing it or setting it to an empty string clears the description. Changes to surrounding whitespace alone are not sent to the API.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
 			},
 			"team_type": schema.StringAttribute{
-				MarkdownDescription: "Team type (OPEN, MEMBER_INVITE, EXTERNAL, ORG_ADMIN_MANAGED)",
-				Required:            true,
+				MarkdownDescription: "Team type (OPEN, MEMBER_INVITE, EXTERNAL, ORG_ADMIN_MANAGED). Required unless the provider sets default_team_type. " +
+					"Membership of EXTERNAL teams is synced from an external identity provider and cannot be managed through `members`; ORG_ADMIN_MANAGED teams cannot set `members` either. " +
+					"The Teams API cannot change the type of a team, so changing it creates a new team with the same display name, description and members; the team gets a new ID. " +
+					"The old team is then deleted, archived or kept like on destroy, following `deletion_policy` and `skip_destroy`. " +
+					"This is an in-place update rather than a replacement, since a replacement destroys the old team first and would lose members not listed in `members`. " +
+					"Members are not carried over to EXTERNAL and ORG_ADMIN_MANAGED teams.",
+				Optional: true,
+				Computed: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("OPEN", "MEMBER_INVITE", "EXTERNAL", "ORG_ADMIN_MANAGED"),
+					stringvalidator.OneOf(teamTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"site_id": schema.StringAttribute{
@@ -84,11 +135,32 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "Organization identifier",
+				MarkdownDescription: "Organization identifier. Defaults to the provider org_id; set it to manage a team of another organization with the same provider. Changing it forces a new team.",
+				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"expected_organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization the team must belong to. When set, reading a team of another organization fails instead of binding it to this resource, " +
+					"e.g. when several organizations are managed with one provider. It is checked on every refresh once it is in state, so it guards an import from the next plan on. " +
+					"It must match `organization_id` if both are set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"creator_id": schema.StringAttribute{
-				MarkdownDescription: "Creator identifier",
+				MarkdownDescription: "Account ID of the team creator, i.e. the identity the provider authenticates as. The Teams API does not accept a creator on create, so this is read-only.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"raw_json": schema.StringAttribute{
+				MarkdownDescription: "Raw JSON of the last team response from the API, to help report API changes. Only set when the provider enables `expose_raw_json`.",
 				Computed:            true,
 			},
 			"state": schema.StringAttribute{
@@ -96,22 +168,216 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 			},
 			"members": schema.SetNestedAttribute{
-				MarkdownDescription: "Team members",
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "Team members. When set, the listed accounts are added to the team and accounts removed from the list are removed from it; " +
+					"other members of the team are left alone unless `manage_all_members` is enabled. Leave it out to not manage members. An empty set removes every member and requires `manage_all_members`. An account listed more than once is added once.",
+				Optional: true,
+				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"account_id": schema.StringAttribute{
 							MarkdownDescription: "Account ID of the team member",
 							Required:            true,
+							Validators: []validator.String{
+								accountIdValidator{},
+							},
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Email address of the team member, populated when `resolve_member_emails` is enabled",
+							Computed:            true,
+						},
+						"member_type": schema.StringAttribute{
+							MarkdownDescription: "Marks the member as `INTERNAL` or `EXTERNAL` (a guest or external collaborator). Leave it unset for internal members. " +
+								"The Teams API does not distinguish member types, so the value is only recorded in state and may only be set on EXTERNAL teams.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("INTERNAL", "EXTERNAL"),
+							},
 						},
 					},
 				},
 			},
+			"manage_all_members": schema.BoolAttribute{
+				MarkdownDescription: "Make `members` authoritative: every member of the team that is not listed, including members added in Atlassian or by other tools, " +
+					"is removed on the next apply. Enabling this on an existing team can remove many members at once, review the plan before applying. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Labels to categorize the team, e.g. by cost center or owner. The Teams API has no labels or metadata, " +
+					"so labels are only recorded in Terraform state and are not persisted to Atlassian. They are empty after an import.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"external_reference": schema.StringAttribute{
+				MarkdownDescription: "Reserved for an external (SCIM/IdP) identifier of the team. The public Teams API does not support external identifiers, so setting this attribute is an error.",
+				Optional:            true,
+			},
+			"deletion_policy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the team when the resource is destroyed: `delete` deletes it, `archive` archives it so it can be restored later. " +
+					"Archived teams still count against organization limits. Defaults to `delete`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("delete"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("delete", "archive"),
+				},
+			},
+			"skip_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Only remove the team from Terraform state on destroy, leaving the team and its members untouched in Atlassian. Takes precedence over `deletion_policy`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"force_delete": schema.BoolAttribute{
+				MarkdownDescription: "Unarchive an archived team before deleting it if the API refuses to delete it while archived. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"retry_delete_conflicts": schema.BoolAttribute{
+				MarkdownDescription: "Retry a delete refused with a conflict, e.g. while removing the team's members is still pending, up to 4 times with exponential backoff. " +
+					"Defaults to `true`; set it to `false` to fail on the first conflict.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"auto_unarchive": schema.BoolAttribute{
+				MarkdownDescription: "Unarchive the team on the next apply if it is archived, e.g. after importing an archived team, before its members are changed. " +
+					"Without it, member changes to an archived team fail at plan time. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "On create, adopt an existing team with the same display name instead of creating a duplicate, e.g. after a create that failed on the Terraform side. " +
+					"The lookup is not atomic with the create, so a team created concurrently may still be duplicated. Creation fails if several teams share the display name. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"resolve_member_emails": schema.BoolAttribute{
+				MarkdownDescription: "Look up the email address of every member when reading the team. This costs one extra API call per member. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"fail_on_member_error": schema.BoolAttribute{
+				MarkdownDescription: "Report membership problems, such as members set on an EXTERNAL team, as errors instead of warnings. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
+func (r *TeamResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var externalReference types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("external_reference"), &externalReference)...)
+
+	// Fail instead of silently ignoring a value the API cannot store
+	if !externalReference.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("external_reference"),
+			"External Reference Not Supported",
+			"The public Atlassian Teams API has no field for an external team identifier, so external_reference cannot be set. "+
+				"Teams synced from an identity provider are created as EXTERNAL teams by the IdP integration instead.",
+		)
+	}
+}
+
+// ConfigValidators holds the checks that span several attributes
+func (r *TeamResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		teamMembersTypeValidator{},
+		teamMemberTypeValidator{},
+		teamEmptyMembersValidator{},
+		teamExpectedOrganizationValidator{},
+	}
+}
+
+// ModifyPlan summarizes the members the apply will add and remove, which
+// reconciliation would otherwise only reveal at apply time. Terraform has no
+// informational severity, so the summary is a warning. An archived team is
+// planned to be unarchived if auto_unarchive is set, otherwise member changes
+// to it are refused.
+func (r *TeamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is reconciled when the team is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data TeamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior TeamResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// A new type recreates the team, see Update
+	if !req.State.Raw.IsNull() && !data.TeamType.IsUnknown() && data.TeamType.ValueString() != prior.TeamType.ValueString() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("creator_id"), types.StringUnknown())...)
+		retired := "deleted"
+		if data.SkipDestroy.ValueBool() {
+			retired = "kept, as skip_destroy is set"
+		} else if data.DeletionPolicy.ValueString() == "archive" {
+			retired = "archived, as deletion_policy is archive"
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("team_type"),
+			"Team Will Be Recreated",
+			fmt.Sprintf("The Teams API cannot change the type of a team, so applying this plan replaces team %s with a new %s team "+
+				"with the same display name, description and members. The new team gets a new ID, and team %s is %s.",
+				prior.ID.ValueString(), data.TeamType.ValueString(), prior.ID.ValueString(), retired),
+		)
+	}
+
+	// Planning the new state makes the apply unarchive the team even without other changes
+	archived := prior.State.ValueString() == "ARCHIVED"
+	if archived && data.AutoUnarchive.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("state"), "ACTIVE")...)
+		archived = false
+	}
+
+	if data.TeamType.ValueString() == "EXTERNAL" {
+		return
+	}
+
+	desired, ok := plannedTeamMembers(ctx, data.Members, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	current, _ := plannedTeamMembers(ctx, prior.Members, &resp.Diagnostics)
+
+	toAdd, toRemove := diffTeamMembers(current, desired)
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return
+	}
+	if archived {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("members"),
+			"Team Is Archived",
+			fmt.Sprintf("Team %q is archived, so its members cannot be changed. "+
+				"Set auto_unarchive = true to unarchive the team during apply, or unarchive it in Atlassian Administration first.", data.DisplayName.ValueString()),
+		)
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("members"),
+		"Planned Team Membership Changes",
+		fmt.Sprintf("Applying this plan adds %d and removes %d members of team %q.", len(toAdd), len(toRemove), data.DisplayName.ValueString()),
+	)
+}
+
 func (r *TeamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -142,42 +408,85 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Create API request body from model
-	createReq := &CreateTeamRequest{
-		DisplayName: data.DisplayName.ValueString(),
-		Description: data.Description.ValueString(),
-		TeamType:    data.TeamType.ValueString(),
-		SiteId:      data.SiteId.ValueString(),
+	// Fall back to the provider default team type
+	if data.TeamType.IsNull() || data.TeamType.IsUnknown() {
+		if r.client.DefaultTeamType == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("team_type"),
+				"Missing Team Type",
+				"team_type must be set on the resource when the provider does not configure default_team_type.",
+			)
+			return
+		}
+		data.TeamType = types.StringValue(r.client.DefaultTeamType)
 	}
 
-	team, err := r.client.CreateTeam(createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create team, got error: %s", err))
+	checkTeamMemberConstraints(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	planMembers := data.Members
+
+	var team *TeamResponseWithMembers
+	if data.AdoptExisting.ValueBool() {
+		team = r.adoptExistingTeam(ctx, r.clientFor(ctx, &data), &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if team == nil {
+		// Create API request body from model
+		createReq := &CreateTeamRequest{
+			DisplayName: data.DisplayName.ValueString(),
+			Description: data.Description.ValueString(),
+			TeamType:    data.TeamType.ValueString(),
+			SiteId:      data.SiteId.ValueString(),
+		}
+
+		var err error
+		team, err = r.clientFor(ctx, &data).CreateTeam(createReq)
+		if err != nil {
+			addTeamClientError(&resp.Diagnostics, fmt.Sprintf("Unable to create team, got error: %s", err)+permissionHint(err), err)
+			return
+		}
+	}
+
+	// A known organization_id at create was configured, so it overrides the provider org_id
+	if !data.OrganizationId.IsUnknown() && data.OrganizationId.ValueString() != "" {
+		resp.Diagnostics.Append(setOrganizationOverride(ctx, resp.Private, data.OrganizationId.ValueString())...)
+	}
 
 	// Map response body to schema and populate Computed attribute values
 	data.ID = types.StringValue(team.TeamID)
 	data.OrganizationId = types.StringValue(team.OrganizationId)
 	data.CreatorId = types.StringValue(team.CreatorId)
 	data.State = types.StringValue(team.State)
+	data.RawJSON = rawJSONValue(r.client, team.RawJSON)
 
-	// Set members from response if available
-	if team.Members != nil {
-		memberElements := make([]attr.Value, len(team.Members))
-		memberType := types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"account_id": types.StringType,
-			},
-		}
-		for i, member := range team.Members {
-			memberObj, _ := types.ObjectValue(memberType.AttrTypes, map[string]attr.Value{
-				"account_id": types.StringValue(member.AccountID),
-			})
-			memberElements[i] = memberObj
+	// Members left out of the configuration are not managed unless membership
+	// is authoritative; they stay null, so that reads skip fetching them
+	if (planMembers.IsNull() || planMembers.IsUnknown()) && !data.ManageAllMembers.ValueBool() {
+		data.Members = types.SetNull(teamMemberObjectType)
+	} else if team.Members != nil {
+		data.Members = teamMembersToSet(team.Members, nil)
+	}
+
+	// Apply configured members to the new (or adopted) team
+	desired, ok := plannedTeamMembers(ctx, planMembers, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ok {
+		// Only authoritative management removes members of an adopted team, or the creator, that are not listed
+		current := team.Members
+		if !data.ManageAllMembers.ValueBool() {
+			current = listedTeamMembers(current, desired)
 		}
-		setVal, _ := types.SetValue(memberType, memberElements)
-		data.Members = setVal
+
+		// State is saved even if members failed, so the created team stays tracked
+		r.applyTeamMembers(ctx, &data, current, desired, &resp.Diagnostics)
+		r.awaitAddedTeamMembers(ctx, &data, current, &resp.Diagnostics)
 	}
 
 	// Write logs using the tflog package
@@ -197,28 +506,128 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get team from API
-	team, err := r.client.GetTeam(data.ID.ValueString())
+	client := r.clientFor(ctx, &data)
+
+	siteId := client.SiteId
+	if !data.SiteId.IsNull() {
+		siteId = data.SiteId.ValueString()
+	}
+
+	// Members are only read back when they are managed inline, to avoid
+	// paging through large teams that are managed elsewhere
+	var team *TeamResponse
+	var err error
+	if !data.Members.IsNull() && !data.Members.IsUnknown() {
+		var teamWithMembers *TeamResponseWithMembers
+		teamWithMembers, err = client.GetTeamWithMembers(data.ID.ValueString(), siteId)
+		if err == nil {
+			// Unless membership is authoritative, members not listed in the
+			// resource are not tracked, so they do not show up as drift
+			listed, _ := plannedTeamMembers(ctx, data.Members, &resp.Diagnostics)
+			members := teamWithMembers.Members
+			if !data.ManageAllMembers.ValueBool() {
+				members = listedTeamMembers(members, listed)
+			}
+			members = withMemberTypes(members, listed)
+
+			var emails map[string]string
+			if data.ResolveMemberEmails.ValueBool() {
+				emails, err = resolveMemberEmails(client, members)
+				if err != nil {
+					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve member emails, got error: %s", err)+permissionHint(err))
+					return
+				}
+			}
+			data.Members = teamMembersToSet(members, emails)
+			team = &TeamResponse{
+				TeamID:         teamWithMembers.TeamID,
+				DisplayName:    teamWithMembers.DisplayName,
+				Description:    teamWithMembers.Description,
+				TeamType:       teamWithMembers.TeamType,
+				OrganizationId: teamWithMembers.OrganizationId,
+				CreatorId:      teamWithMembers.CreatorId,
+				State:          teamWithMembers.State,
+				RawJSON:        teamWithMembers.RawJSON,
+			}
+		}
+	} else {
+		team, err = client.GetTeamInSite(data.ID.ValueString(), siteId)
+	}
 	if err != nil {
-		if err.Error() == fmt.Sprintf("team not found: %s", data.ID.ValueString()) {
+		if isNotFoundError(err) && r.client.TreatMissingAsDeleted {
 			// Team was deleted outside Terraform
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team, got error: %s", err)+missingHint(err)+permissionHint(err))
+		return
+	}
+
+	// Refuse to bind state to a team of another organization, e.g. after importing the wrong ID.
+	// The organization_id in state is not checked against, it may hold the wrong team's organization.
+	orgId, configured := r.client.OrgId, "the provider is configured for"
+	if override := organizationOverride(ctx, req.Private, &resp.Diagnostics); override != "" {
+		orgId, configured = override, "the resource manages teams of"
+	}
+	if orgId != "" && team.OrganizationId != "" && team.OrganizationId != orgId {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Team Belongs To Another Organization",
+			fmt.Sprintf("Team %s belongs to organization %s, but %s organization %s. "+
+				"Check that the correct team ID was imported, or configure the provider for the team's organization.",
+				data.ID.ValueString(), team.OrganizationId, configured, orgId),
+		)
+		return
+	}
+
+	if expected := data.ExpectedOrganizationId.ValueString(); expected != "" && team.OrganizationId != expected {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expected_organization_id"),
+			"Unexpected Team Organization",
+			fmt.Sprintf("Team %s belongs to organization %s, but expected_organization_id is %s. "+
+				"Check that the correct team ID was imported or that expected_organization_id is correct.",
+				data.ID.ValueString(), team.OrganizationId, expected),
+		)
 		return
 	}
 
 	// Update the model with the team data
-	data.DisplayName = types.StringValue(team.DisplayName)
-	data.Description = types.StringValue(team.Description)
+	data.DisplayName = configuredOrRemote(data.DisplayName, team.DisplayName)
+	data.Description = configuredOrRemote(data.Description, team.Description)
 	data.TeamType = types.StringValue(team.TeamType)
 	data.OrganizationId = types.StringValue(team.OrganizationId)
 	data.CreatorId = types.StringValue(team.CreatorId)
 	data.State = types.StringValue(team.State)
+	data.RawJSON = rawJSONValue(r.client, team.RawJSON)
 
-	// Note: TeamResponse doesn't include members, so we keep existing members in state
-	// For full member sync, we would need a separate API call to fetch members
+	// Provider-only settings are not returned by the API, default them after import
+	if data.ForceDelete.IsNull() {
+		data.ForceDelete = types.BoolValue(false)
+	}
+	if data.RetryDeleteConflicts.IsNull() {
+		data.RetryDeleteConflicts = types.BoolValue(true)
+	}
+	if data.AutoUnarchive.IsNull() {
+		data.AutoUnarchive = types.BoolValue(false)
+	}
+	if data.FailOnMemberError.IsNull() {
+		data.FailOnMemberError = types.BoolValue(false)
+	}
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+	if data.ResolveMemberEmails.IsNull() {
+		data.ResolveMemberEmails = types.BoolValue(false)
+	}
+	if data.DeletionPolicy.IsNull() {
+		data.DeletionPolicy = types.StringValue("delete")
+	}
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(false)
+	}
+	if data.ManageAllMembers.IsNull() {
+		data.ManageAllMembers = types.BoolValue(false)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -234,24 +643,139 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// Update team basic information
-	updateReq := &UpdateTeamRequest{
-		DisplayName: data.DisplayName.ValueString(),
-		Description: data.Description.ValueString(),
+	var prior TeamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	team, err := r.client.UpdateTeam(data.ID.ValueString(), updateReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update team, got error: %s", err))
+	checkTeamMemberConstraints(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Update computed fields from response
-	data.DisplayName = types.StringValue(team.DisplayName)
-	data.Description = types.StringValue(team.Description)
-	data.TeamType = types.StringValue(team.TeamType)
-	data.OrganizationId = types.StringValue(team.OrganizationId)
-	data.State = types.StringValue(team.State)
+	// The plan only unarchives the team when auto_unarchive is set, see ModifyPlan
+	unarchived := false
+	if prior.State.ValueString() == "ARCHIVED" && data.AutoUnarchive.ValueBool() {
+		if err := unarchiveTeam(r.clientFor(ctx, &data), data.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unarchive team, got error: %s", err)+permissionHint(err))
+			return
+		}
+		unarchived = true
+	}
+
+	// The Teams API cannot change the type of a team, so a new team with the
+	// planned display name and description replaces it. This happens here
+	// rather than through RequiresReplace: Terraform destroys the old team
+	// before creating the new one, so members not listed in the resource would
+	// be lost. The old team is then retired like on destroy.
+	if data.TeamType.ValueString() != prior.TeamType.ValueString() {
+		client := r.clientFor(ctx, &data)
+		if !data.SiteId.IsNull() {
+			client.SiteId = data.SiteId.ValueString()
+		}
+		old := Team{
+			TeamID:         prior.ID.ValueString(),
+			DisplayName:    data.DisplayName.ValueString(),
+			Description:    data.Description.ValueString(),
+			TeamType:       prior.TeamType.ValueString(),
+			OrganizationId: prior.OrganizationId.ValueString(),
+		}
+
+		team, err := client.RecreateTeamWithType(old, data.TeamType.ValueString(), nil)
+		if err != nil {
+			addTeamClientError(&resp.Diagnostics, fmt.Sprintf("Unable to change team type, got error: %s", err)+permissionHint(err), err)
+			return
+		}
+		data.ID = types.StringValue(team.TeamID)
+		data.TeamType = types.StringValue(team.TeamType)
+		data.OrganizationId = types.StringValue(team.OrganizationId)
+		data.CreatorId = types.StringValue(team.CreatorId)
+		data.State = types.StringValue(team.State)
+		data.RawJSON = rawJSONValue(r.client, team.RawJSON)
+
+		retired := data
+		retired.ID = prior.ID
+		r.retireTeam(ctx, &retired, team.TeamID, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// The new team is complete, only the old one is left over, so track the new one
+			data.Members = prior.Members
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	} else if sameIgnoringSpace(data.DisplayName, prior.DisplayName.ValueString()) && sameIgnoringSpace(data.Description, prior.Description.ValueString()) {
+		// Terraform requires the plan to match the configuration, so a change
+		// that only adds or removes surrounding whitespace still shows up as a
+		// diff. It needs no PATCH, the computed fields keep their prior values.
+		data.TeamType = prior.TeamType
+		data.OrganizationId = prior.OrganizationId
+		data.CreatorId = prior.CreatorId
+		data.State = prior.State
+		data.RawJSON = prior.RawJSON
+	} else {
+		// Update team basic information
+		updateReq := &UpdateTeamRequest{
+			DisplayName: data.DisplayName.ValueString(),
+			Description: data.Description.ValueStringPointer(),
+		}
+
+		team, err := r.clientFor(ctx, &data).UpdateTeam(data.ID.ValueString(), updateReq)
+		if err != nil {
+			addTeamClientError(&resp.Diagnostics, fmt.Sprintf("Unable to update team, got error: %s", err)+permissionHint(err), err)
+			return
+		}
+
+		// Update computed fields from response
+		data.DisplayName = configuredOrRemote(data.DisplayName, team.DisplayName)
+		data.Description = configuredOrRemote(data.Description, team.Description)
+		data.TeamType = types.StringValue(team.TeamType)
+		data.OrganizationId = types.StringValue(team.OrganizationId)
+		data.CreatorId = types.StringValue(team.CreatorId)
+		data.State = types.StringValue(team.State)
+		data.RawJSON = rawJSONValue(r.client, team.RawJSON)
+	}
+	if unarchived {
+		data.State = types.StringValue("ACTIVE")
+	}
+
+	// Members left out of the configuration are not managed, keep the prior value
+	desired, ok := plannedTeamMembers(ctx, data.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ok {
+		current, _ := plannedTeamMembers(ctx, prior.Members, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// State only tracks listed members until membership becomes authoritative,
+		// so read all of them once when manage_all_members is switched on
+		if data.ManageAllMembers.ValueBool() && !prior.ManageAllMembers.ValueBool() {
+			client := r.clientFor(ctx, &data)
+			siteId := client.SiteId
+			if !data.SiteId.IsNull() {
+				siteId = data.SiteId.ValueString()
+			}
+			var err error
+			current, err = client.fetchAllTeamMembers(client.getOrgIdentifier(), data.ID.ValueString(), siteId)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team members, got error: %s", err)+permissionHint(err))
+				return
+			}
+		}
+
+		// Unchanged members need no API calls, keep the prior value
+		toAdd, toRemove := diffTeamMembers(current, desired)
+		if len(toAdd) == 0 && len(toRemove) == 0 && slices.Equal(withMemberTypes(current, desired), current) &&
+			data.ResolveMemberEmails.Equal(prior.ResolveMemberEmails) {
+			data.Members = prior.Members
+		} else {
+			r.applyTeamMembers(ctx, &data, current, desired, &resp.Diagnostics)
+		}
+	} else {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("members"), &data.Members)...)
+	}
 
 	tflog.Trace(ctx, "updated a team resource")
 
@@ -269,16 +793,659 @@ func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteTeam(data.ID.ValueString())
+	r.retireTeam(ctx, &data, "", &resp.Diagnostics)
+}
+
+// retireTeam deletes the team of data, or archives or keeps it if
+// deletion_policy or skip_destroy ask for it. It runs on destroy and for the
+// old team when a type change replaces it with the team replacedBy.
+func (r *TeamResource) retireTeam(ctx context.Context, data *TeamResourceModel, replacedBy string, diags *diag.Diagnostics) {
+	if data.SkipDestroy.ValueBool() {
+		tflog.Warn(ctx, "skip_destroy is set, keeping the team without deleting it", map[string]any{"team_id": data.ID.ValueString()})
+		detail := fmt.Sprintf("skip_destroy is set, so team %s was removed from Terraform state but still exists in Atlassian.", data.ID.ValueString())
+		if replacedBy != "" {
+			detail = fmt.Sprintf("skip_destroy is set, so team %s was left in place after team %s replaced it. It still exists in Atlassian, but Terraform manages only the new team.",
+				data.ID.ValueString(), replacedBy)
+		}
+		diags.AddWarning("Team Not Deleted", detail)
+		return
+	}
+
+	if data.DeletionPolicy.ValueString() == "archive" {
+		if err := archiveTeam(r.clientFor(ctx, data), data.ID.ValueString()); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to archive team, got error: %s", err)+permissionHint(err))
+			return
+		}
+
+		tflog.Trace(ctx, "archived a team resource")
+		return
+	}
+
+	client := r.clientFor(ctx, data)
+	err := client.DeleteTeam(data.ID.ValueString())
+	if errors.Is(err, ErrTeamDeleteConflict) && (data.ForceDelete.ValueBool() || data.RetryDeleteConflicts.ValueBool()) {
+		err = retryTeamDelete(ctx, client, data.ID.ValueString(), err, data.ForceDelete.ValueBool(), data.RetryDeleteConflicts.ValueBool())
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete team, got error: %s", err))
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete team, got error: %s", err)+permissionHint(err))
 		return
 	}
 
 	tflog.Trace(ctx, "deleted a team resource")
 }
 
+// archiveTeam archives a single team, treating an already archived or missing team as success
+func archiveTeam(client *AtlassianClient, teamID string) error {
+	archiveResp, err := client.ArchiveTeams(client.getOrgIdentifier(), []string{teamID})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, e := range archiveResp.Errors {
+		if e.TeamID != teamID {
+			continue
+		}
+		team, getErr := client.GetTeam(teamID)
+		if isNotFoundError(getErr) || (getErr == nil && team.State == "ARCHIVED") {
+			return nil
+		}
+		return fmt.Errorf("%s - %s", e.Code, e.Message)
+	}
+
+	return nil
+}
+
+// adoptExistingTeam looks up a team with the planned display name and, if one
+// exists, updates it to match the plan. It returns nil if no team was found.
+func (r *TeamResource) adoptExistingTeam(ctx context.Context, client *AtlassianClient, data *TeamResourceModel, diags *diag.Diagnostics) *TeamResponseWithMembers {
+	siteId := client.SiteId
+	if !data.SiteId.IsNull() {
+		siteId = data.SiteId.ValueString()
+	}
+
+	existing, err := client.GetTeamByName(client.getOrgIdentifier(), siteId, data.DisplayName.ValueString())
+	if errors.Is(err, ErrTeamNameNotFound) {
+		return nil
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("adopt_existing"), "Client Error", fmt.Sprintf("Unable to look up existing team to adopt, got error: %s", err)+permissionHint(err))
+		return nil
+	}
+
+	if existing.TeamType != data.TeamType.ValueString() {
+		diags.AddAttributeError(
+			path.Root("team_type"),
+			"Cannot Adopt Existing Team",
+			fmt.Sprintf("Team %s named %q has team type %s, but %s is configured. The team type of an existing team cannot be changed.",
+				existing.TeamID, existing.DisplayName, existing.TeamType, data.TeamType.ValueString()),
+		)
+		return nil
+	}
+
+	tflog.Info(ctx, "adopting existing team", map[string]any{"team_id": existing.TeamID, "display_name": existing.DisplayName})
+
+	updated, err := client.UpdateTeam(existing.TeamID, &UpdateTeamRequest{
+		DisplayName: data.DisplayName.ValueString(),
+		Description: data.Description.ValueStringPointer(),
+	})
+	if err != nil {
+		addTeamClientError(diags, fmt.Sprintf("Unable to update adopted team %s, got error: %s", existing.TeamID, err)+permissionHint(err), err)
+		return nil
+	}
+
+	adopted := &TeamResponseWithMembers{
+		TeamID:         updated.TeamID,
+		DisplayName:    updated.DisplayName,
+		Description:    updated.Description,
+		TeamType:       updated.TeamType,
+		OrganizationId: updated.OrganizationId,
+		CreatorId:      updated.CreatorId,
+		State:          updated.State,
+	}
+
+	// Record the adopted team's current members, configured members are applied on top of them
+	members, err := client.fetchAllTeamMembers(client.getOrgIdentifier(), existing.TeamID, siteId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read members of adopted team %s, got error: %s", existing.TeamID, err)+permissionHint(err))
+		return nil
+	}
+	adopted.Members = members
+
+	return adopted
+}
+
+// resolveMemberEmails looks up the email address of each member. The Teams
+// API lists members by account ID only and has no variant with profiles, so
+// every member takes one profile request.
+func resolveMemberEmails(client *AtlassianClient, members []TeamMember) (map[string]string, error) {
+	emails := make(map[string]string, len(members))
+	for _, member := range members {
+		user, err := client.GetUser(member.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", member.AccountID, err)
+		}
+		emails[member.AccountID] = user.Email
+	}
+	return emails, nil
+}
+
+// teamMemberObjectType is the element type of the members set attribute
+var teamMemberObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"account_id":  types.StringType,
+		"email":       types.StringType,
+		"member_type": types.StringType,
+	},
+}
+
+// teamMembersToSet converts API members into the members set attribute value.
+// Emails are taken from emails if present, otherwise left null.
+func teamMembersToSet(members []TeamMember, emails map[string]string) types.Set {
+	memberElements := make([]attr.Value, len(members))
+	for i, member := range members {
+		email := types.StringNull()
+		if e, ok := emails[member.AccountID]; ok {
+			email = types.StringValue(e)
+		}
+		memberType := types.StringNull()
+		if member.MemberType != "" {
+			memberType = types.StringValue(member.MemberType)
+		}
+		memberElements[i] = types.ObjectValueMust(teamMemberObjectType.AttrTypes, map[string]attr.Value{
+			"account_id":  types.StringValue(member.AccountID),
+			"email":       email,
+			"member_type": memberType,
+		})
+	}
+	return types.SetValueMust(teamMemberObjectType, memberElements)
+}
+
+// plannedTeamMembers converts the members set attribute into API members. It
+// returns false if members are null or unknown, i.e. not managed by the resource.
+// Account IDs are deduplicated because the membership endpoints require unique
+// items, and elements that differ only in computed fields such as email map
+// to the same member.
+func plannedTeamMembers(ctx context.Context, set types.Set, diags *diag.Diagnostics) ([]TeamMember, bool) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, false
+	}
+
+	var models []TeamMemberModel
+	diags.Append(set.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, false
+	}
+
+	members := make([]TeamMember, 0, len(models))
+	seen := make(map[string]bool, len(models))
+	for _, model := range models {
+		accountID := model.AccountID.ValueString()
+		if seen[accountID] {
+			continue
+		}
+		seen[accountID] = true
+		members = append(members, TeamMember{AccountID: accountID, MemberType: model.MemberType.ValueString()})
+	}
+	return members, true
+}
+
+// sameIgnoringSpace reports whether value equals remote apart from
+// surrounding whitespace
+func sameIgnoringSpace(value types.String, remote string) bool {
+	return strings.TrimSpace(value.ValueString()) == strings.TrimSpace(remote)
+}
+
+// configuredOrRemote returns the remote value of a string attribute, unless it
+// only differs from the configured value in surrounding whitespace, so values
+// the API trims do not show up as drift
+func configuredOrRemote(configured types.String, remote string) types.String {
+	if !configured.IsNull() && !configured.IsUnknown() && sameIgnoringSpace(configured, remote) {
+		return configured
+	}
+	return types.StringValue(remote)
+}
+
+// withMemberTypes returns members with the member types configured in typed,
+// which the API does not return
+func withMemberTypes(members, typed []TeamMember) []TeamMember {
+	memberTypes := make(map[string]string, len(typed))
+	for _, member := range typed {
+		memberTypes[member.AccountID] = member.MemberType
+	}
+
+	result := make([]TeamMember, len(members))
+	for i, member := range members {
+		result[i] = TeamMember{AccountID: member.AccountID, MemberType: memberTypes[member.AccountID]}
+	}
+	return result
+}
+
+// diffTeamMembers returns the members of desired missing from current, and
+// the members of current missing from desired. Members are matched by account
+// ID only, so a member whose other attributes changed is neither removed nor
+// re-added. The Teams API has no member roles or other per-member settings to
+// update; member_type is state-only and applied in place by withMemberTypes.
+func diffTeamMembers(current, desired []TeamMember) (toAdd, toRemove []TeamMember) {
+	currentIDs := make(map[string]bool, len(current))
+	for _, member := range current {
+		currentIDs[member.AccountID] = true
+	}
+	desiredIDs := make(map[string]bool, len(desired))
+	for _, member := range desired {
+		desiredIDs[member.AccountID] = true
+		if !currentIDs[member.AccountID] {
+			toAdd = append(toAdd, member)
+		}
+	}
+	for _, member := range current {
+		if !desiredIDs[member.AccountID] {
+			toRemove = append(toRemove, member)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// memberVisibilityTimeout bounds how long Create waits for added members to
+// show up in the member list
+const memberVisibilityTimeout = 30 * time.Second
+
+// awaitAddedTeamMembers waits until the members recorded in data that were
+// not in current are listed by the API, so the refresh after create does not
+// report them as missing. Rejected members are not in data and not waited for.
+func (r *TeamResource) awaitAddedTeamMembers(ctx context.Context, data *TeamResourceModel, current []TeamMember, diags *diag.Diagnostics) {
+	if diags.HasError() || data.TeamType.ValueString() == "EXTERNAL" {
+		return
+	}
+	applied, _ := plannedTeamMembers(ctx, data.Members, diags)
+	added, _ := diffTeamMembers(current, applied)
+	if len(added) == 0 {
+		return
+	}
+
+	client := r.clientFor(ctx, data)
+	siteId := client.SiteId
+	if !data.SiteId.IsNull() {
+		siteId = data.SiteId.ValueString()
+	}
+	if _, err := client.WaitForTeamMembers(client.getOrgIdentifier(), data.ID.ValueString(), siteId, added, memberVisibilityTimeout); err != nil {
+		diags.AddAttributeWarning(
+			path.Root("members"),
+			"Team Members Not Yet Visible",
+			fmt.Sprintf("The members were added, but the API does not list all of them yet: %s. The next refresh may report them as missing until it catches up.", err),
+		)
+	}
+}
+
+// listedTeamMembers returns the members of current whose account is in listed
+func listedTeamMembers(current, listed []TeamMember) []TeamMember {
+	accountIDs := make(map[string]bool, len(listed))
+	for _, member := range listed {
+		accountIDs[member.AccountID] = true
+	}
+
+	members := make([]TeamMember, 0, len(listed))
+	for _, member := range current {
+		if accountIDs[member.AccountID] {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// applyTeamMembers adds and removes members so the team matches desired, and
+// records in data the members the team actually has afterwards. Membership of
+// EXTERNAL teams is left untouched, checkTeamMemberConstraints has already
+// reported it. data.Members is always known on return, so state can be saved
+// even when diags holds errors.
+func (r *TeamResource) applyTeamMembers(ctx context.Context, data *TeamResourceModel, current, desired []TeamMember, diags *diag.Diagnostics) {
+	applied := desired
+	if data.TeamType.ValueString() != "EXTERNAL" {
+		applied = withMemberTypes(r.syncTeamMembers(ctx, data, current, desired, diags), desired)
+	}
+
+	var emails map[string]string
+	if data.ResolveMemberEmails.ValueBool() && !diags.HasError() {
+		var err error
+		emails, err = resolveMemberEmails(r.clientFor(ctx, data), applied)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to resolve member emails, got error: %s", err)+permissionHint(err))
+		}
+	}
+	data.Members = teamMembersToSet(applied, emails)
+}
+
+// syncTeamMembers calls the membership endpoints for the difference between
+// current and desired. It returns current minus the removals and plus the
+// additions the API confirmed, leaving out accounts the API reported errors for.
+func (r *TeamResource) syncTeamMembers(ctx context.Context, data *TeamResourceModel, current, desired []TeamMember, diags *diag.Diagnostics) []TeamMember {
+	client := r.clientFor(ctx, data)
+	toAdd, toRemove := diffTeamMembers(current, desired)
+
+	var memberErrors []PublicApiMembershipCodedError
+	failed := make(map[string]bool)
+	added := make(map[string]bool)
+	removed := make(map[string]bool)
+
+	if len(toAdd) > 0 {
+		addResp, err := client.AddTeamMembers(client.getOrgIdentifier(), data.ID.ValueString(), toAdd)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to add team members, got error: %s", err)+permissionHint(err))
+			return current
+		}
+		for _, member := range addResp.Members {
+			added[member.AccountID] = true
+		}
+		memberErrors = append(memberErrors, addResp.Errors...)
+	}
+
+	if len(toRemove) > 0 {
+		removeResp, err := client.RemoveTeamMembers(client.getOrgIdentifier(), data.ID.ValueString(), toRemove)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove team members, got error: %s", err)+permissionHint(err))
+		} else {
+			for _, member := range toRemove {
+				removed[member.AccountID] = true
+			}
+			for _, e := range removeResp.Errors {
+				// Already gone, e.g. removed concurrently, which is what the removal wanted
+				if isMemberNotFound(e) {
+					tflog.Debug(ctx, "Team member to remove was not a member", map[string]any{"account_id": e.AccountID, "code": e.Code})
+					continue
+				}
+				memberErrors = append(memberErrors, e)
+			}
+		}
+	}
+
+	for _, e := range memberErrors {
+		failed[e.AccountID] = true
+
+		summary := "Team Member Not Updated"
+		detail := fmt.Sprintf("Account %s: %s - %s", e.AccountID, e.Code, e.Message)
+		if data.FailOnMemberError.ValueBool() {
+			diags.AddAttributeError(path.Root("members"), summary, detail)
+		} else {
+			diags.AddAttributeWarning(path.Root("members"), summary, detail)
+		}
+	}
+
+	applied := make([]TeamMember, 0, len(desired))
+	var addedIDs, removedIDs, failedIDs []string
+	for _, member := range current {
+		if !removed[member.AccountID] || failed[member.AccountID] {
+			applied = append(applied, member)
+		} else {
+			removedIDs = append(removedIDs, member.AccountID)
+		}
+	}
+	for _, member := range toAdd {
+		if added[member.AccountID] && !failed[member.AccountID] {
+			applied = append(applied, member)
+			addedIDs = append(addedIDs, member.AccountID)
+		}
+	}
+	for _, e := range memberErrors {
+		failedIDs = append(failedIDs, e.AccountID)
+	}
+
+	// Record the applied membership changes for audits, e.g. with TF_LOG=INFO
+	if len(toAdd) > 0 || len(toRemove) > 0 {
+		tflog.Info(ctx, "Reconciled team members", map[string]any{
+			"team_id":       data.ID.ValueString(),
+			"added_count":   len(addedIDs),
+			"added":         addedIDs,
+			"removed_count": len(removedIDs),
+			"removed":       removedIDs,
+			"failed":        failedIDs,
+		})
+	}
+	return applied
+}
+
+// checkTeamMemberConstraints reports members configured on team types that do
+// not support managing membership through the Teams API
+func checkTeamMemberConstraints(data *TeamResourceModel, diags *diag.Diagnostics) {
+	if data.TeamType.ValueString() != "EXTERNAL" || data.Members.IsNull() || data.Members.IsUnknown() || len(data.Members.Elements()) == 0 {
+		return
+	}
+
+	summary := "Members Not Supported For EXTERNAL Teams"
+	detail := "Atlassian syncs the membership of EXTERNAL teams from an external identity provider, " +
+		"so members cannot be added or removed through the Teams API. Remove members from this resource " +
+		"or manage membership in your identity provider."
+
+	if data.FailOnMemberError.ValueBool() {
+		diags.AddAttributeError(path.Root("members"), summary, detail)
+		return
+	}
+	diags.AddAttributeWarning(path.Root("members"), summary, detail)
+}
+
+// retryTeamDelete retries a delete the API refused. An archived team is
+// unarchived first if unarchive is set; otherwise a 409 Conflict, e.g. while
+// member removals are still pending, is retried with backoff if retryConflicts
+// is set. Other errors return deleteErr.
+func retryTeamDelete(ctx context.Context, client *AtlassianClient, teamID string, deleteErr error, unarchive, retryConflicts bool) error {
+	team, err := client.GetTeam(teamID)
+	if err != nil {
+		return fmt.Errorf("%w (unable to check team state: %s)", deleteErr, err)
+	}
+
+	if team.State != "ARCHIVED" {
+		if !retryConflicts || !hasStatusCode(deleteErr, http.StatusConflict) {
+			return deleteErr
+		}
+		tflog.Debug(ctx, "retrying team delete after conflict", map[string]any{"team_id": teamID})
+		return client.DeleteTeamRetryingConflicts(teamID)
+	}
+
+	if !unarchive {
+		return deleteErr
+	}
+	tflog.Debug(ctx, "unarchiving team before delete", map[string]any{"team_id": teamID})
+
+	if err := unarchiveTeam(client, teamID); err != nil {
+		return fmt.Errorf("unable to unarchive team before delete: %w", err)
+	}
+
+	if err := client.DeleteTeam(teamID); err != nil {
+		return fmt.Errorf("team was unarchived but deletion still failed: %w", err)
+	}
+
+	return nil
+}
+
+// unarchiveTeam unarchives a single team
+func unarchiveTeam(client *AtlassianClient, teamID string) error {
+	result, err := client.UnarchiveTeams(client.getOrgIdentifier(), []string{teamID})
+	if err != nil {
+		return err
+	}
+	return result.Err("unarchiving")
+}
+
+// clientFor returns the client for a team's requests, targeting the
+// resource's organization_id when it is known
+func (r *TeamResource) clientFor(ctx context.Context, data *TeamResourceModel) *AtlassianClient {
+	client := r.client.WithContext(ctx)
+	if data.OrganizationId.IsUnknown() {
+		return client
+	}
+	return client.WithOrgId(data.OrganizationId.ValueString())
+}
+
+// organizationOverrideKey is the private state key holding the organization_id
+// that was configured or imported for a team. Read checks teams against it
+// instead of the provider org_id; organization_id in state cannot tell, as Read
+// copies it from the team.
+const organizationOverrideKey = "organization_override"
+
+// privateState is implemented by the private state of resource requests and
+// responses
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setOrganizationOverride records orgId as the organization the team is
+// explicitly managed in
+func setOrganizationOverride(ctx context.Context, private privateState, orgId string) diag.Diagnostics {
+	value, err := json.Marshal(orgId)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to Save Private State", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, organizationOverrideKey, value)
+}
+
+// organizationOverride returns the organization recorded by
+// setOrganizationOverride, or "" if there is none
+func organizationOverride(ctx context.Context, private privateState, diags *diag.Diagnostics) string {
+	value, getDiags := private.GetKey(ctx, organizationOverrideKey)
+	diags.Append(getDiags...)
+	if len(value) == 0 {
+		return ""
+	}
+	var orgId string
+	if err := json.Unmarshal(value, &orgId); err != nil {
+		diags.AddError("Unable to Read Private State", err.Error())
+		return ""
+	}
+	return orgId
+}
+
+// UpgradeState upgrades state written by earlier provider versions
+func (r *TeamResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeTeamStateV0},
+	}
+}
+
+// upgradeTeamStateV0 upgrades version 0 state, which was written by provider
+// versions with fewer attributes. Attributes missing from it are null, except
+// those with a default, which get the default so the next plan shows no
+// change. Computed attributes are filled in by the next Read.
+func upgradeTeamStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	rawState, err := req.RawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Unable to read version 0 state of the team: %s", err),
+		)
+		return
+	}
+	resp.State.Raw = rawState
+
+	var data TeamResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Description.IsNull() {
+		data.Description = types.StringValue("")
+	}
+	if data.DeletionPolicy.IsNull() {
+		data.DeletionPolicy = types.StringValue("delete")
+	}
+	if data.RetryDeleteConflicts.IsNull() {
+		data.RetryDeleteConflicts = types.BoolValue(true)
+	}
+	for _, flag := range []*types.Bool{
+		&data.ManageAllMembers, &data.ForceDelete, &data.AutoUnarchive, &data.FailOnMemberError,
+		&data.AdoptExisting, &data.ResolveMemberEmails, &data.SkipDestroy,
+	} {
+		if flag.IsNull() {
+			*flag = types.BoolValue(false)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ImportState imports a team by its ID, or by <org_id>/<team_id> for a team
+// of another organization than the provider's
 func (r *TeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by team ID
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	orgId, teamId, err := parseTeamImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a team ID or <org_id>/<team_id>, got %q: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), teamId)...)
+	if orgId != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), orgId)...)
+		resp.Diagnostics.Append(setOrganizationOverride(ctx, resp.Private, orgId)...)
+	}
+}
+
+// parseTeamImportID splits an import ID into its optional organization ID and
+// the team ID
+func parseTeamImportID(id string) (orgId, teamId string, err error) {
+	parts := strings.Split(id, "/")
+	switch len(parts) {
+	case 1:
+		teamId = parts[0]
+	case 2:
+		orgId, teamId = parts[0], parts[1]
+		if orgId == "" {
+			return "", "", errors.New("the organization ID is empty")
+		}
+		if err := validateOrgId(orgId); err != nil {
+			return "", "", err
+		}
+	default:
+		return "", "", errors.New("too many '/' separators")
+	}
+
+	if teamId == "" || strings.IndexFunc(teamId, unicode.IsSpace) >= 0 {
+		return "", "", errors.New("the team ID is empty or contains whitespace")
+	}
+	return orgId, teamId, nil
+}
+
+// rawJSONValue returns raw as a string if the provider exposes raw API
+// responses, null otherwise
+func rawJSONValue(client *AtlassianClient, raw json.RawMessage) types.String {
+	if !client.ExposeRawJSON || len(raw) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(string(raw))
+}
+
+// teamRequestFields maps the fields of team create and update requests to the
+// attributes they are set from
+var teamRequestFields = []struct {
+	field     string
+	attribute string
+}{
+	{"displayName", "display_name"},
+	{"description", "description"},
+	{"teamType", "team_type"},
+	{"siteId", "site_id"},
+}
+
+// addTeamClientError adds a client error to diags. When the API rejected the
+// request naming one of its fields, the error is attached to the attribute
+// the field is set from, so editors highlight it.
+func addTeamClientError(diags *diag.Diagnostics, detail string, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		for _, f := range teamRequestFields {
+			if strings.Contains(apiErr.Body, f.field) {
+				diags.AddAttributeError(path.Root(f.attribute), "Client Error", detail)
+				return
+			}
+		}
+	}
+	diags.AddError("Client Error", detail)
 }
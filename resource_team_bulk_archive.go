@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TeamBulkArchiveResource{}
+
+func NewTeamBulkArchiveResource() resource.Resource {
+	return &TeamBulkArchiveResource{}
+}
+
+// TeamBulkArchiveResource keeps a set of teams archived.
+type TeamBulkArchiveResource struct {
+	client *AtlassianClient
+}
+
+// TeamBulkArchiveResourceModel describes the resource data model.
+type TeamBulkArchiveResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	TeamIDs types.Set    `tfsdk:"team_ids"`
+}
+
+func (r *TeamBulkArchiveResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_bulk_archive"
+}
+
+func (r *TeamBulkArchiveResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Archives a set of teams of the provider organization and unarchives them when destroyed. " +
+			"To archive teams matching a predicate, pass the IDs from the `atlassian_teams` data source with `filter_type` or `filter_state`. " +
+			"Teams managed by `atlassian_team` resources should use their `deletion_policy` instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the bulk archive, derived from the team IDs at creation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the teams to keep archived. Teams are archived in requests of up to 100 IDs. " +
+					"Teams unarchived outside Terraform are archived again on the next apply; deleted teams are dropped.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *TeamBulkArchiveResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TeamBulkArchiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TeamBulkArchiveResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slices.Sort(teamIDs)
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(teamIDs, ","))))[:16])
+
+	r.bulkOperation(ctx, "archive", teamIDs, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "created a team bulk archive resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkArchiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TeamBulkArchiveResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// One listing is cheaper than reading every team
+	teams, err := r.client.WithContext(ctx).GetAllTeams(r.client.getOrgIdentifier(), r.client.SiteId, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err)+permissionHint(err))
+		return
+	}
+	states := make(map[string]string, len(teams))
+	for _, team := range teams {
+		states[team.TeamID] = team.State
+	}
+
+	// Teams that are no longer archived drop out of state, so the plan archives them again
+	archived := make([]string, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		if states[teamID] == "ARCHIVED" {
+			archived = append(archived, teamID)
+		}
+	}
+	data.TeamIDs = accountIDsToSet(archived)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkArchiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior TeamBulkArchiveResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired, current []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &desired, false)...)
+	resp.Diagnostics.Append(prior.TeamIDs.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toArchive, toUnarchive := diffTeamMembers(accountIDsToMembers(current), accountIDsToMembers(desired))
+	r.bulkOperation(ctx, "archive", membersToAccountIDs(toArchive), &resp.Diagnostics)
+	r.bulkOperation(ctx, "unarchive", membersToAccountIDs(toUnarchive), &resp.Diagnostics)
+
+	tflog.Trace(ctx, "updated a team bulk archive resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkArchiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TeamBulkArchiveResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.bulkOperation(ctx, "unarchive", teamIDs, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "deleted a team bulk archive resource")
+}
+
+// bulkOperation archives or unarchives teams in chunks the bulk endpoints
+// accept. Teams the API reports errors for are added to diags as errors.
+func (r *TeamBulkArchiveResource) bulkOperation(ctx context.Context, operation string, teamIDs []string, diags *diag.Diagnostics) {
+	client := r.client.WithContext(ctx)
+	orgID := client.getOrgIdentifier()
+
+	for chunk := range slices.Chunk(teamIDs, maxTeamsPerBulkOperation) {
+		var result *PublicApiBulkOperationResponse
+		var err error
+		if operation == "archive" {
+			result, err = client.ArchiveTeams(orgID, chunk)
+		} else {
+			result, err = client.UnarchiveTeams(orgID, chunk)
+		}
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to %s teams, got error: %s", operation, err)+permissionHint(err))
+			return
+		}
+
+		var bulkErr *BulkOperationError
+		if errors.As(result.Err(strings.TrimSuffix(operation, "e")+"ing"), &bulkErr) {
+			for _, e := range bulkErr.Failed {
+				diags.AddAttributeError(
+					path.Root("team_ids"),
+					"Bulk Team Operation Error",
+					fmt.Sprintf("Unable to %s team %s: %s - %s", operation, e.TeamID, e.Code, e.Message),
+				)
+			}
+		}
+		tflog.Info(ctx, "Bulk team operation", map[string]any{
+			"operation":  operation,
+			"successful": len(result.SuccessfulTeamIds),
+			"failed":     len(result.Errors),
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTeamBulkArchiveResourceLifecycle(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	r := &TeamBulkArchiveResource{client: client}
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	nullState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	// More teams than one bulk request accepts, plus one that does not exist
+	teamIDs := []string{"missing"}
+	fake.mu.Lock()
+	for i := range 150 {
+		teamID := fmt.Sprintf("bulk-%d", i)
+		fake.teams[teamID] = &TeamResponse{TeamID: teamID, State: "ACTIVE"}
+		teamIDs = append(teamIDs, teamID)
+	}
+	fake.mu.Unlock()
+
+	plan := nullState
+	if diags := plan.Set(ctx, TeamBulkArchiveResourceModel{ID: types.StringUnknown(), TeamIDs: accountIDsToSet(teamIDs)}); diags.HasError() {
+		t.Fatalf("Unable to build plan: %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: nullState}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("Expected one error for the missing team, got: %v", createResp.Diagnostics)
+	}
+	if got := len(fake.requestsMatching("/teams/archive")); got != 2 {
+		t.Errorf("Expected 151 teams to be archived in 2 requests, got %d", got)
+	}
+	for _, teamID := range teamIDs[1:] {
+		if state := fake.team(teamID).State; state != "ARCHIVED" {
+			t.Fatalf("Expected team %s to be archived, got %s", teamID, state)
+		}
+	}
+
+	// A team unarchived outside Terraform drops out of state
+	fake.mu.Lock()
+	fake.teams["bulk-0"].State = "ACTIVE"
+	fake.mu.Unlock()
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", readResp.Diagnostics)
+	}
+	var read TeamBulkArchiveResourceModel
+	readResp.State.Get(ctx, &read)
+	if want := accountIDsToSet(teamIDs[2:]); !read.TeamIDs.Equal(want) {
+		t.Errorf("Expected only the archived teams in state, got %d", len(read.TeamIDs.Elements()))
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete failed: %v", deleteResp.Diagnostics)
+	}
+	for _, teamID := range teamIDs[1:] {
+		if state := fake.team(teamID).State; state != "ACTIVE" {
+			t.Fatalf("Expected team %s to be unarchived, got %s", teamID, state)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TeamBulkDeleteResource{}
+
+func NewTeamBulkDeleteResource() resource.Resource {
+	return &TeamBulkDeleteResource{}
+}
+
+// TeamBulkDeleteResource deletes a set of teams.
+type TeamBulkDeleteResource struct {
+	client *AtlassianClient
+}
+
+// TeamBulkDeleteResourceModel describes the resource data model.
+type TeamBulkDeleteResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	TeamIDs types.Set    `tfsdk:"team_ids"`
+}
+
+func (r *TeamBulkDeleteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_bulk_delete"
+}
+
+func (r *TeamBulkDeleteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deletes a set of teams of the provider organization, for cleanups of teams not managed by `atlassian_team` resources. " +
+			"The Teams API has no bulk delete, so every team is deleted with its own request. " +
+			"Deleted teams cannot be restored: destroying the resource or removing a team from `team_ids` only drops it from state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the bulk delete, derived from the team IDs at creation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the teams to delete. Teams that do not exist count as deleted. " +
+					"Teams that still exist on the next refresh, because their deletion failed, are deleted again on the next apply.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *TeamBulkDeleteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TeamBulkDeleteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TeamBulkDeleteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slices.Sort(teamIDs)
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(teamIDs, ","))))[:16])
+
+	r.deleteTeams(ctx, teamIDs, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "created a team bulk delete resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkDeleteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TeamBulkDeleteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// One listing is cheaper than reading every team
+	teams, err := r.client.WithContext(ctx).GetAllTeams(r.client.getOrgIdentifier(), r.client.SiteId, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err)+permissionHint(err))
+		return
+	}
+	existing := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		existing[team.TeamID] = true
+	}
+
+	// Teams that still exist drop out of state, so the plan deletes them again
+	deleted := make([]string, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		if !existing[teamID] {
+			deleted = append(deleted, teamID)
+		}
+	}
+	data.TeamIDs = accountIDsToSet(deleted)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkDeleteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior TeamBulkDeleteResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired, current []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &desired, false)...)
+	resp.Diagnostics.Append(prior.TeamIDs.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only added teams need deleting, removed teams are gone already
+	added, _ := diffTeamMembers(accountIDsToMembers(current), accountIDsToMembers(desired))
+	r.deleteTeams(ctx, membersToAccountIDs(added), &resp.Diagnostics)
+
+	tflog.Trace(ctx, "updated a team bulk delete resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkDeleteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deleted teams cannot be restored, so there is nothing to undo
+	tflog.Trace(ctx, "deleted a team bulk delete resource")
+}
+
+// deleteTeams deletes teamIDs. Teams that could not be deleted are added to
+// diags as errors.
+func (r *TeamBulkDeleteResource) deleteTeams(ctx context.Context, teamIDs []string, diags *diag.Diagnostics) {
+	if len(teamIDs) == 0 {
+		return
+	}
+
+	client := r.client.WithContext(ctx)
+	result, err := client.DeleteTeams(client.getOrgIdentifier(), teamIDs)
+
+	var bulkErr *BulkOperationError
+	if errors.As(err, &bulkErr) {
+		for _, e := range bulkErr.Failed {
+			diags.AddAttributeError(
+				path.Root("team_ids"),
+				"Bulk Team Operation Error",
+				fmt.Sprintf("Unable to delete team %s: %s - %s", e.TeamID, e.Code, e.Message),
+			)
+		}
+	} else if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete teams, got error: %s", err)+permissionHint(err))
+		return
+	}
+
+	tflog.Info(ctx, "Bulk team delete", map[string]any{
+		"successful": len(result.SuccessfulTeamIds),
+		"failed":     len(result.Errors),
+	})
+}
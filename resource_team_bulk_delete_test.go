@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTeamBulkDeleteResourceLifecycle(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	r := &TeamBulkDeleteResource{client: client}
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	nullState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	teamIDs := []string{"missing"}
+	for _, name := range []string{"Platform", "Design", "Archived"} {
+		team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: name, TeamType: "OPEN"})
+		if err != nil {
+			t.Fatalf("CreateTeam failed: %v", err)
+		}
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+	refused := teamIDs[3]
+	fake.mu.Lock()
+	fake.teams[refused].State = "ARCHIVED"
+	fake.refuseArchivedDeletes = true
+	fake.mu.Unlock()
+
+	// Every team gets its own request, one refused delete does not stop the others
+	plan := nullState
+	if diags := plan.Set(ctx, TeamBulkDeleteResourceModel{ID: types.StringUnknown(), TeamIDs: accountIDsToSet(teamIDs)}); diags.HasError() {
+		t.Fatalf("Unable to build plan: %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: nullState}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("Expected one error for the archived team, got: %v", createResp.Diagnostics)
+	}
+	if detail := createResp.Diagnostics.Errors()[0].Detail(); detail != "Unable to delete team "+refused+": CONFLICT - archived teams cannot be deleted" {
+		t.Errorf("Expected the per-team error of the archived team, got %q", detail)
+	}
+	if got := len(fake.requestsMatching("DELETE ")); got != len(teamIDs) {
+		t.Errorf("Expected one delete request per team, got %d", got)
+	}
+	for _, teamID := range teamIDs[1:3] {
+		if fake.team(teamID) != nil {
+			t.Errorf("Expected team %s to be deleted", teamID)
+		}
+	}
+
+	// The team that still exists drops out of state, so the next apply retries it
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", readResp.Diagnostics)
+	}
+	var read TeamBulkDeleteResourceModel
+	readResp.State.Get(ctx, &read)
+	if want := accountIDsToSet(teamIDs[:3]); !read.TeamIDs.Equal(want) {
+		t.Errorf("Expected only the deleted teams in state, got %v", read.TeamIDs)
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TeamBulkDescriptionResource{}
+
+func NewTeamBulkDescriptionResource() resource.Resource {
+	return &TeamBulkDescriptionResource{}
+}
+
+// TeamBulkDescriptionResource applies one description to a set of teams.
+type TeamBulkDescriptionResource struct {
+	client *AtlassianClient
+}
+
+// TeamBulkDescriptionResourceModel describes the resource data model.
+type TeamBulkDescriptionResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	TeamIDs     types.Set    `tfsdk:"team_ids"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *TeamBulkDescriptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_bulk_description"
+}
+
+func (r *TeamBulkDescriptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Applies a standard description to a set of teams of the provider organization. " +
+			"The Teams API has no bulk update, so every team is updated with its own request. " +
+			"Destroying the resource or removing a team from `team_ids` leaves the descriptions as they are. " +
+			"Teams managed by `atlassian_team` resources should set their `description` instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the bulk description, derived from the team IDs at creation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the teams to apply the description to. " +
+					"Teams whose description is changed outside Terraform are updated again on the next apply; deleted teams are dropped.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description applied to every team. An empty string clears the descriptions.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(360),
+				},
+			},
+		},
+	}
+}
+
+func (r *TeamBulkDescriptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AtlassianClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AtlassianClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TeamBulkDescriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TeamBulkDescriptionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slices.Sort(teamIDs)
+	data.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(teamIDs, ","))))[:16])
+
+	r.updateDescriptions(ctx, teamIDs, data.Description.ValueString(), &resp.Diagnostics)
+
+	tflog.Trace(ctx, "created a team bulk description resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkDescriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TeamBulkDescriptionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var teamIDs []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// One listing is cheaper than reading every team
+	teams, err := r.client.WithContext(ctx).GetAllTeams(r.client.getOrgIdentifier(), r.client.SiteId, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list teams, got error: %s", err)+permissionHint(err))
+		return
+	}
+	descriptions := make(map[string]string, len(teams))
+	for _, team := range teams {
+		descriptions[team.TeamID] = team.Description
+	}
+
+	// Teams with another description drop out of state, so the plan updates them again
+	applied := make([]string, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		if description, ok := descriptions[teamID]; ok && description == data.Description.ValueString() {
+			applied = append(applied, teamID)
+		}
+	}
+	data.TeamIDs = accountIDsToSet(applied)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkDescriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior TeamBulkDescriptionResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired, current []string
+	resp.Diagnostics.Append(data.TeamIDs.ElementsAs(ctx, &desired, false)...)
+	resp.Diagnostics.Append(prior.TeamIDs.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new description goes to every team, otherwise only added teams need it;
+	// removed teams keep their description
+	toUpdate := desired
+	if data.Description.Equal(prior.Description) {
+		added, _ := diffTeamMembers(accountIDsToMembers(current), accountIDsToMembers(desired))
+		toUpdate = membersToAccountIDs(added)
+	}
+	r.updateDescriptions(ctx, toUpdate, data.Description.ValueString(), &resp.Diagnostics)
+
+	tflog.Trace(ctx, "updated a team bulk description resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamBulkDescriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Descriptions are left as they are, there is no prior description to restore
+	tflog.Trace(ctx, "deleted a team bulk description resource")
+}
+
+// updateDescriptions sets the description of teamIDs. Teams that could not
+// be updated are added to diags as errors.
+func (r *TeamBulkDescriptionResource) updateDescriptions(ctx context.Context, teamIDs []string, description string, diags *diag.Diagnostics) {
+	if len(teamIDs) == 0 {
+		return
+	}
+
+	client := r.client.WithContext(ctx)
+	result, err := client.UpdateTeamsDescription(client.getOrgIdentifier(), teamIDs, description)
+
+	var bulkErr *BulkOperationError
+	if errors.As(err, &bulkErr) {
+		for _, e := range bulkErr.Failed {
+			diags.AddAttributeError(
+				path.Root("team_ids"),
+				"Bulk Team Operation Error",
+				fmt.Sprintf("Unable to update the description of team %s: %s - %s", e.TeamID, e.Code, e.Message),
+			)
+		}
+	} else if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update team descriptions, got error: %s", err)+permissionHint(err))
+		return
+	}
+
+	tflog.Info(ctx, "Bulk team description update", map[string]any{
+		"successful": len(result.SuccessfulTeamIds),
+		"failed":     len(result.Errors),
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTeamBulkDescriptionResourceLifecycle(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	r := &TeamBulkDescriptionResource{client: client}
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	nullState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	teamIDs := []string{"missing"}
+	for _, name := range []string{"Platform", "Design"} {
+		team, err := client.CreateTeam(&CreateTeamRequest{DisplayName: name, Description: "Old", TeamType: "OPEN"})
+		if err != nil {
+			t.Fatalf("CreateTeam failed: %v", err)
+		}
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+
+	plan := nullState
+	if diags := plan.Set(ctx, TeamBulkDescriptionResourceModel{ID: types.StringUnknown(), TeamIDs: accountIDsToSet(teamIDs), Description: types.StringValue("Owned by ops")}); diags.HasError() {
+		t.Fatalf("Unable to build plan: %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: nullState}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	if createResp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("Expected one error for the missing team, got: %v", createResp.Diagnostics)
+	}
+	for _, teamID := range teamIDs[1:] {
+		if description := fake.team(teamID).Description; description != "Owned by ops" {
+			t.Fatalf("Expected team %s to get the description, got %q", teamID, description)
+		}
+	}
+
+	// A team whose description was changed outside Terraform drops out of state
+	fake.mu.Lock()
+	fake.teams[teamIDs[1]].Description = "Changed"
+	fake.mu.Unlock()
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read failed: %v", readResp.Diagnostics)
+	}
+	var read TeamBulkDescriptionResourceModel
+	readResp.State.Get(ctx, &read)
+	if want := accountIDsToSet(teamIDs[2:]); !read.TeamIDs.Equal(want) {
+		t.Errorf("Expected only the team with the description in state, got %v", read.TeamIDs)
+	}
+
+	// Destroying the resource leaves the descriptions alone
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() || fake.team(teamIDs[2]).Description != "Owned by ops" {
+		t.Errorf("Expected Delete to keep the descriptions, got %v", deleteResp.Diagnostics)
+	}
+}
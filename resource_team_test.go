@@ -1,7 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 )
 
 func TestTeamTypeValidation(t *testing.T) {
@@ -45,3 +66,1539 @@ func TestInvalidTeamType(t *testing.T) {
 		})
 	}
 }
+
+func testMembersSet(t *testing.T, accountIDs ...string) types.Set {
+	t.Helper()
+
+	members := make([]TeamMember, len(accountIDs))
+	for i, accountID := range accountIDs {
+		members[i] = TeamMember{AccountID: accountID}
+	}
+	return teamMembersToSet(members, nil)
+}
+
+func TestCheckTeamMemberConstraints(t *testing.T) {
+	data := TeamResourceModel{
+		TeamType:          types.StringValue("EXTERNAL"),
+		Members:           testMembersSet(t, "557058:abc"),
+		FailOnMemberError: types.BoolValue(false),
+	}
+
+	var diags diag.Diagnostics
+	checkTeamMemberConstraints(&data, &diags)
+	if diags.WarningsCount() != 1 || diags.HasError() {
+		t.Errorf("Expected a single warning for members on an EXTERNAL team, got: %v", diags)
+	}
+
+	data.FailOnMemberError = types.BoolValue(true)
+	diags = nil
+	checkTeamMemberConstraints(&data, &diags)
+	if !diags.HasError() {
+		t.Error("Expected an error when fail_on_member_error is set")
+	}
+
+	data.TeamType = types.StringValue("OPEN")
+	diags = nil
+	checkTeamMemberConstraints(&data, &diags)
+	if len(diags) != 0 {
+		t.Errorf("Expected no diagnostics for an OPEN team, got: %v", diags)
+	}
+}
+
+// testTeamResourceCall holds the plan/state plumbing needed to invoke
+// TeamResource CRUD methods directly in tests
+type testTeamResourceCall struct {
+	t        *testing.T
+	resource *TeamResource
+	schema   schema.Schema
+
+	// privates holds the private state of created and imported teams by ID
+	privates map[string]privateState
+}
+
+// newPrivateState initializes the private state of a response, as the
+// framework does before calling the resource
+func newPrivateState[T any](private **T) {
+	*private = new(T)
+}
+
+// restorePrivateState sets the private state of a request to a value saved
+// from an earlier response
+func restorePrivateState[T any](private *T, saved privateState) {
+	if value, ok := saved.(T); ok {
+		*private = value
+	}
+}
+
+func newTestTeamResourceCall(t *testing.T, client *AtlassianClient) *testTeamResourceCall {
+	t.Helper()
+
+	r := &TeamResource{client: client}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	return &testTeamResourceCall{t: t, resource: r, schema: schemaResp.Schema, privates: map[string]privateState{}}
+}
+
+func (c *testTeamResourceCall) emptyState() tfsdk.State {
+	return tfsdk.State{
+		Schema: c.schema,
+		Raw:    tftypes.NewValue(c.schema.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+func (c *testTeamResourceCall) plan(data TeamResourceModel) tfsdk.Plan {
+	c.t.Helper()
+
+	state := c.emptyState()
+	if diags := state.Set(context.Background(), &data); diags.HasError() {
+		c.t.Fatalf("Unable to build plan: %v", diags)
+	}
+	return tfsdk.Plan{Schema: c.schema, Raw: state.Raw}
+}
+
+func (c *testTeamResourceCall) state(data TeamResourceModel) tfsdk.State {
+	c.t.Helper()
+
+	state := c.emptyState()
+	if diags := state.Set(context.Background(), &data); diags.HasError() {
+		c.t.Fatalf("Unable to build state: %v", diags)
+	}
+	return state
+}
+
+func (c *testTeamResourceCall) model(state tfsdk.State) TeamResourceModel {
+	c.t.Helper()
+
+	var data TeamResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		c.t.Fatalf("Unable to read state: %v", diags)
+	}
+	return data
+}
+
+func (c *testTeamResourceCall) create(data TeamResourceModel) (TeamResourceModel, diag.Diagnostics) {
+	c.t.Helper()
+
+	resp := &resource.CreateResponse{State: c.emptyState()}
+	newPrivateState(&resp.Private)
+	c.resource.Create(context.Background(), resource.CreateRequest{Plan: c.plan(data)}, resp)
+	if resp.Diagnostics.HasError() {
+		return TeamResourceModel{}, resp.Diagnostics
+	}
+	created := c.model(resp.State)
+	c.privates[created.ID.ValueString()] = resp.Private
+	return created, resp.Diagnostics
+}
+
+func (c *testTeamResourceCall) read(data TeamResourceModel) (TeamResourceModel, bool, diag.Diagnostics) {
+	c.t.Helper()
+
+	req := resource.ReadRequest{State: c.state(data)}
+	restorePrivateState(&req.Private, c.privates[data.ID.ValueString()])
+	resp := &resource.ReadResponse{State: c.state(data), Private: req.Private}
+	c.resource.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		return TeamResourceModel{}, !resp.State.Raw.IsNull(), resp.Diagnostics
+	}
+	return c.model(resp.State), true, resp.Diagnostics
+}
+
+func (c *testTeamResourceCall) update(prior, planned TeamResourceModel) (TeamResourceModel, diag.Diagnostics) {
+	c.t.Helper()
+
+	resp := &resource.UpdateResponse{State: c.state(prior)}
+	c.resource.Update(context.Background(), resource.UpdateRequest{Plan: c.plan(planned), State: c.state(prior)}, resp)
+	if resp.Diagnostics.HasError() {
+		return TeamResourceModel{}, resp.Diagnostics
+	}
+	return c.model(resp.State), resp.Diagnostics
+}
+
+func (c *testTeamResourceCall) delete(data TeamResourceModel) diag.Diagnostics {
+	c.t.Helper()
+
+	resp := &resource.DeleteResponse{State: c.state(data)}
+	c.resource.Delete(context.Background(), resource.DeleteRequest{State: c.state(data)}, resp)
+	return resp.Diagnostics
+}
+
+// testTeamResourceModel returns a planned model with unknown computed values
+func testTeamResourceModel(displayName, description, teamType string) TeamResourceModel {
+	return TeamResourceModel{
+		ID:                     types.StringUnknown(),
+		DisplayName:            types.StringValue(displayName),
+		Description:            types.StringValue(description),
+		TeamType:               types.StringValue(teamType),
+		SiteId:                 types.StringNull(),
+		OrganizationId:         types.StringUnknown(),
+		CreatorId:              types.StringUnknown(),
+		State:                  types.StringUnknown(),
+		Members:                types.SetUnknown(teamMemberObjectType),
+		ManageAllMembers:       types.BoolValue(false),
+		ForceDelete:            types.BoolValue(false),
+		RetryDeleteConflicts:   types.BoolValue(true),
+		AutoUnarchive:          types.BoolValue(false),
+		FailOnMemberError:      types.BoolValue(false),
+		AdoptExisting:          types.BoolValue(false),
+		ResolveMemberEmails:    types.BoolValue(false),
+		DeletionPolicy:         types.StringValue("delete"),
+		ExternalReference:      types.StringNull(),
+		SkipDestroy:            types.BoolValue(false),
+		Labels:                 types.MapNull(types.StringType),
+		ExpectedOrganizationId: types.StringNull(),
+		RawJSON:                types.StringUnknown(),
+	}
+}
+
+func TestTeamResourceLifecycle(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "Platform team", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if created.ID.ValueString() == "" || created.OrganizationId.ValueString() != "org-1" || created.State.ValueString() != "ACTIVE" {
+		t.Fatalf("Unexpected state after create: %+v", created)
+	}
+
+	read, exists, diags := call.read(created)
+	if diags.HasError() || !exists {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if read.DisplayName.ValueString() != "Platform" {
+		t.Errorf("Expected display name Platform, got %s", read.DisplayName.ValueString())
+	}
+
+	planned := read
+	planned.DisplayName = types.StringValue("Platform Engineering")
+	updated, diags := call.update(read, planned)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if fake.team(updated.ID.ValueString()).DisplayName != "Platform Engineering" {
+		t.Errorf("Expected display name to be updated on the server")
+	}
+
+	if diags := call.delete(updated); diags.HasError() {
+		t.Fatalf("Delete failed: %v", diags)
+	}
+	if fake.team(updated.ID.ValueString()) != nil {
+		t.Error("Expected team to be deleted on the server")
+	}
+
+	if _, exists, diags := call.read(updated); diags.HasError() || exists {
+		t.Errorf("Expected Read of a deleted team to remove it from state, got exists=%v diags=%v", exists, diags)
+	}
+}
+
+func TestTeamResourceUpdateClearsDescription(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "Platform team", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	planned := created
+	planned.Description = types.StringValue("")
+	updated, diags := call.update(created, planned)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+
+	if got := fake.team(created.ID.ValueString()).Description; got != "" {
+		t.Errorf("Expected description to be cleared on the server, got %q", got)
+	}
+	if updated.Description.ValueString() != "" {
+		t.Errorf("Expected empty description in state, got %q", updated.Description.ValueString())
+	}
+}
+
+func TestTeamResourceReadRefreshesInlineMembers(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.ManageAllMembers = types.BoolValue(true)
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, err := client.AddTeamMembers("org-1", created.ID.ValueString(), []TeamMember{{AccountID: "a"}}); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	read, _, diags := call.read(created)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if want := testMembersSet(t, "a"); !read.Members.Equal(want) {
+		t.Errorf("Expected members %v, got %v", want, read.Members)
+	}
+}
+
+func TestTeamResourceCreateAdoptsExistingTeam(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	existing, err := client.CreateTeam(&CreateTeamRequest{DisplayName: "Platform", Description: "old", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	planned := testTeamResourceModel("Platform", "new", "OPEN")
+	planned.AdoptExisting = types.BoolValue(true)
+	adopted, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	if adopted.ID.ValueString() != existing.TeamID {
+		t.Errorf("Expected existing team %s to be adopted, got %s", existing.TeamID, adopted.ID.ValueString())
+	}
+	if got := fake.team(existing.TeamID).Description; got != "new" {
+		t.Errorf("Expected adopted team to be updated to the planned description, got %q", got)
+	}
+
+	planned = testTeamResourceModel("Platform", "new", "MEMBER_INVITE")
+	planned.AdoptExisting = types.BoolValue(true)
+	if _, diags := call.create(planned); !diags.HasError() {
+		t.Error("Expected adopting a team with a different team type to fail")
+	}
+}
+
+func TestTeamResourceReadSkipsUnmanagedMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, err := client.AddTeamMembers("org-1", created.ID.ValueString(), []TeamMember{{AccountID: "a"}}); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	before := len(fake.requestsMatching("/members"))
+	read, _, diags := call.read(created)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if fetches := len(fake.requestsMatching("/members")) - before; fetches != 0 || !read.Members.IsNull() {
+		t.Errorf("Expected no member requests for unmanaged members, got %d and members %v", fetches, read.Members)
+	}
+}
+
+func TestTeamResourceReadResolvesMemberEmails(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	fake.setUser(User{AccountID: "a", Email: "a@example.com"})
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	created.ResolveMemberEmails = types.BoolValue(true)
+	read, _, diags := call.read(created)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+
+	want := teamMembersToSet([]TeamMember{{AccountID: "a"}}, map[string]string{"a": "a@example.com"})
+	if !read.Members.Equal(want) {
+		t.Errorf("Expected members %v, got %v", want, read.Members)
+	}
+}
+
+func TestTeamResourceResolvesEachMemberEmailOnce(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	accountIDs := []string{"a", "b", "c"}
+	for _, accountID := range accountIDs {
+		fake.setUser(User{AccountID: accountID, Email: accountID + "@example.com"})
+	}
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, accountIDs...)
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	created.ResolveMemberEmails = types.BoolValue(true)
+	before := len(fake.requestsMatching("/manage/profile"))
+	if _, _, diags := call.read(created); diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+
+	// No endpoint lists members with profiles, so one lookup per member is the floor
+	if lookups := len(fake.requestsMatching("/manage/profile")) - before; lookups != len(accountIDs) {
+		t.Errorf("Expected %d profile lookups, got %d", len(accountIDs), lookups)
+	}
+}
+
+func TestTeamResourceCreateUsesDefaultTeamType(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "")
+	planned.TeamType = types.StringUnknown()
+	if _, diags := call.create(planned); !diags.HasError() {
+		t.Error("Expected a missing team_type to fail without default_team_type")
+	}
+
+	client.DefaultTeamType = "MEMBER_INVITE"
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if created.TeamType.ValueString() != "MEMBER_INVITE" || fake.team(created.ID.ValueString()).TeamType != "MEMBER_INVITE" {
+		t.Errorf("Expected the default team type, got %v", created.TeamType)
+	}
+}
+
+func TestTeamResourceDeleteWithArchivePolicy(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	created.DeletionPolicy = types.StringValue("archive")
+	if diags := call.delete(created); diags.HasError() {
+		t.Fatalf("Delete failed: %v", diags)
+	}
+
+	team := fake.team(created.ID.ValueString())
+	if team == nil || team.State != "ARCHIVED" {
+		t.Errorf("Expected team to be archived instead of deleted, got %+v", team)
+	}
+}
+
+func TestTeamResourceForceDeleteUnarchivesArchivedTeam(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, err := client.ArchiveTeams("org-1", []string{created.ID.ValueString()}); err != nil {
+		t.Fatalf("ArchiveTeams failed: %v", err)
+	}
+	fake.refuseArchivedDeletes = true
+
+	created.ForceDelete = types.BoolValue(true)
+	if diags := call.delete(created); diags.HasError() {
+		t.Fatalf("Delete failed: %v", diags)
+	}
+
+	if fake.team(created.ID.ValueString()) != nil {
+		t.Error("Expected the team to be deleted after unarchiving it")
+	}
+	if unarchives := len(fake.requestsMatching("/teams/unarchive")); unarchives != 1 {
+		t.Errorf("Expected 1 unarchive request, got %d", unarchives)
+	}
+}
+
+func TestTeamResourceForceDeleteFailsWhenUnarchiveFails(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, err := client.ArchiveTeams("org-1", []string{created.ID.ValueString()}); err != nil {
+		t.Fatalf("ArchiveTeams failed: %v", err)
+	}
+	fake.refuseArchivedDeletes = true
+	fake.failStateChanges = true
+
+	created.ForceDelete = types.BoolValue(true)
+	diags = call.delete(created)
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "unable to unarchive team before delete") {
+		t.Fatalf("Expected the failed unarchive to fail the delete, got %v", diags)
+	}
+
+	if team := fake.team(created.ID.ValueString()); team == nil || team.State != "ARCHIVED" {
+		t.Errorf("Expected the team to be kept archived, got %+v", team)
+	}
+}
+
+// testDuplicateMembersSet returns a members set listing account a twice, with
+// elements that differ only in the computed email
+func testDuplicateMembersSet() types.Set {
+	member := func(accountID string, email types.String) attr.Value {
+		return types.ObjectValueMust(teamMemberObjectType.AttrTypes, map[string]attr.Value{
+			"account_id":  types.StringValue(accountID),
+			"email":       email,
+			"member_type": types.StringNull(),
+		})
+	}
+	return types.SetValueMust(teamMemberObjectType, []attr.Value{
+		member("a", types.StringNull()),
+		member("a", types.StringValue("a@example.com")),
+		member("b", types.StringNull()),
+	})
+}
+
+func TestPlannedTeamMembersDedupsAccountIDs(t *testing.T) {
+	var diags diag.Diagnostics
+	members, ok := plannedTeamMembers(context.Background(), testDuplicateMembersSet(), &diags)
+	if diags.HasError() || !ok {
+		t.Fatalf("plannedTeamMembers failed: ok=%v diags=%v", ok, diags)
+	}
+
+	accountIDs := make([]string, len(members))
+	for i, member := range members {
+		accountIDs[i] = member.AccountID
+	}
+	slices.Sort(accountIDs)
+	if !slices.Equal(accountIDs, []string{"a", "b"}) {
+		t.Errorf("Expected deduplicated members [a b], got %v", accountIDs)
+	}
+}
+
+func TestTeamResourceAppliesMembersWithDuplicateAccountIDs(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testDuplicateMembersSet()
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	got := fake.teamMembers(created.ID.ValueString())
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected members [a b] on the server, got %v", got)
+	}
+	if want := testMembersSet(t, "a", "b"); !created.Members.Equal(want) {
+		t.Errorf("Expected members %v in state, got %v", want, created.Members)
+	}
+
+	planned = created
+	planned.Members = testMembersSet(t, "b", "c")
+	if _, diags := call.update(created, planned); diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+
+	got = fake.teamMembers(created.ID.ValueString())
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Expected members [b c] on the server after update, got %v", got)
+	}
+}
+
+func TestTeamResourceReadRejectsTeamOfAnotherOrg(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	// An imported team has no organization_id in state yet, so the provider organization is used
+	imported := created
+	imported.OrganizationId = types.StringNull()
+	client.OrgId = "org-2"
+	if _, _, diags := call.read(imported); !diags.HasError() {
+		t.Error("Expected Read to fail for a team of another organization")
+	}
+}
+
+func TestTeamResourceReadRejectsTeamOfAnotherOrgInState(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	team, err := client.WithOrgId("org-2").CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	// State bound to the wrong team already holds its organization, which must not pass as configured
+	data := testTeamResourceModel("Platform", "", "OPEN")
+	data.ID = types.StringValue(team.TeamID)
+	data.OrganizationId = types.StringValue("org-2")
+	_, _, diags := call.read(data)
+	if !diags.HasError() {
+		t.Fatal("Expected Read to fail for a team of another organization than the provider")
+	}
+	if summary := diags.Errors()[0].Summary(); summary != "Team Belongs To Another Organization" {
+		t.Errorf("Expected the organization error, got %q", summary)
+	}
+}
+
+func TestTeamResourceReadPreservesOrganization(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	// Both a managed team and an import, which starts without organization_id, read back the team's organization
+	imported := created
+	imported.OrganizationId = types.StringNull()
+	for _, data := range []TeamResourceModel{created, imported} {
+		read, _, diags := call.read(data)
+		if diags.HasError() {
+			t.Fatalf("Read failed: %v", diags)
+		}
+		if read.OrganizationId.ValueString() != "org-1" {
+			t.Errorf("Expected organization_id org-1 after read, got %v", read.OrganizationId)
+		}
+	}
+}
+
+func TestTeamResourceValidateConfigRejectsExternalReference(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+
+	for _, value := range []types.String{types.StringNull(), types.StringValue("scim-123")} {
+		data := testTeamResourceModel("Platform", "", "OPEN")
+		data.ExternalReference = value
+
+		resp := &resource.ValidateConfigResponse{}
+		config := tfsdk.Config{Schema: call.schema, Raw: call.plan(data).Raw}
+		call.resource.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		if got, want := resp.Diagnostics.HasError(), !value.IsNull(); got != want {
+			t.Errorf("external_reference %v: expected error %v, got %v", value, want, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTeamResourceDeleteWithSkipDestroy(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	created.SkipDestroy = types.BoolValue(true)
+	diags = call.delete(created)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("Expected Delete to succeed with a warning, got: %v", diags)
+	}
+
+	if team := fake.team(created.ID.ValueString()); team == nil || team.State != "ACTIVE" {
+		t.Errorf("Expected team to be left untouched, got %+v", team)
+	}
+}
+
+func TestTeamResourceOrganizationOverride(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.OrganizationId = types.StringValue("org-2")
+	planned.Members = testMembersSet(t, "a")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if got := fake.team(created.ID.ValueString()).OrganizationId; got != "org-2" {
+		t.Errorf("Expected team to be created in org-2, got %s", got)
+	}
+
+	if _, _, diags := call.read(created); diags.HasError() {
+		t.Errorf("Expected Read of a team in the overridden organization to succeed, got: %v", diags)
+	}
+	if client.OrgId != "org-1" {
+		t.Errorf("Expected the provider client to keep its organization, got %s", client.OrgId)
+	}
+}
+
+func TestTeamResourceCreateRecordsOnlyConfirmedMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	fake.rejectAccount("bad")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "bad")
+	created, diags := call.create(planned)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("Expected Create to succeed with a warning for the rejected member, got: %v", diags)
+	}
+
+	if want := testMembersSet(t, "a"); !created.Members.Equal(want) {
+		t.Errorf("Expected only the confirmed member in state, got %v", created.Members)
+	}
+}
+
+func TestTeamResourceManageAllMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, err := client.AddTeamMembers("org-1", created.ID.ValueString(), []TeamMember{{AccountID: "other"}}); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	// Members added outside Terraform are not tracked by default
+	read, _, diags := call.read(created)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if want := testMembersSet(t, "a"); !read.Members.Equal(want) {
+		t.Errorf("Expected only the listed member in state, got %v", read.Members)
+	}
+
+	// Switching to authoritative membership removes them
+	authoritative := read
+	authoritative.ManageAllMembers = types.BoolValue(true)
+	updated, diags := call.update(read, authoritative)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if got := fake.teamMembers(created.ID.ValueString()); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Expected the unlisted member to be removed, got %v", got)
+	}
+	if want := testMembersSet(t, "a"); !updated.Members.Equal(want) {
+		t.Errorf("Expected members %v in state, got %v", want, updated.Members)
+	}
+}
+
+func TestTeamResourceUpdateSkipsUnchangedMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "b")
+	planned.ResolveMemberEmails = types.BoolValue(true)
+	fake.setUser(User{AccountID: "a", Email: "a@example.com"})
+	fake.setUser(User{AccountID: "b", Email: "b@example.com"})
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	before := len(fake.requestsMatching("/members")) + len(fake.requestsMatching("/manage/profile"))
+	renamed := created
+	renamed.DisplayName = types.StringValue("Platform Engineering")
+	updated, diags := call.update(created, renamed)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+
+	if after := len(fake.requestsMatching("/members")) + len(fake.requestsMatching("/manage/profile")); after != before {
+		t.Errorf("Expected no member API calls on an unrelated change, got %d", after-before)
+	}
+	if !updated.Members.Equal(created.Members) {
+		t.Errorf("Expected members to keep their prior value %v, got %v", created.Members, updated.Members)
+	}
+}
+
+func TestTeamResourceUpdateReadsBackCreator(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	planned := created
+	planned.DisplayName = types.StringValue("Platform Engineering")
+	planned.CreatorId = types.StringUnknown()
+	updated, diags := call.update(created, planned)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if updated.CreatorId.ValueString() != "fake-creator" {
+		t.Errorf("Expected creator_id to be read back, got %v", updated.CreatorId)
+	}
+}
+
+func TestTeamResourceCreateWaitsForAddedMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	client.RetryWaitMin = time.Millisecond
+	fake.rejectAccount("bad")
+	call := newTestTeamResourceCall(t, client)
+
+	fake.delayMemberVisibility(2)
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "bad")
+	_, diags := call.create(planned)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("Expected only the rejected member warning, got: %v", diags)
+	}
+
+	// The rejected member is not waited for, the fetch that lists "a" ends the wait
+	if got := len(fake.requestsMatching("/members")) - len(fake.requestsMatching("/members/add")); got != 3 {
+		t.Errorf("Expected 3 member fetches, got %d", got)
+	}
+}
+
+func TestTeamResourceUpdateToleratesRemovedNonMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "b", "c")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	// "b" leaves the team concurrently, "c" cannot be removed
+	if _, err := client.RemoveTeamMembers("org-1", created.ID.ValueString(), []TeamMember{{AccountID: "b"}}); err != nil {
+		t.Fatalf("RemoveTeamMembers failed: %v", err)
+	}
+	fake.rejectAccount("c")
+
+	shrunk := created
+	shrunk.Members = testMembersSet(t, "a")
+	updated, diags := call.update(created, shrunk)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning for the failed removal, got: %v", diags)
+	}
+	if want := testMembersSet(t, "a", "c"); !updated.Members.Equal(want) {
+		t.Errorf("Expected members %v in state, got %v", want, updated.Members)
+	}
+}
+
+func TestTeamResourceLogsMemberChanges(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "b")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	fake.rejectAccount("bad")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	current := []TeamMember{{AccountID: "a"}, {AccountID: "b"}}
+	desired := []TeamMember{{AccountID: "a"}, {AccountID: "c"}, {AccountID: "bad"}}
+	call.resource.syncTeamMembers(ctx, &created, current, desired, &diags)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Unable to decode log output: %v", err)
+	}
+	var logged map[string]any
+	for _, entry := range entries {
+		if entry["@message"] == "Reconciled team members" {
+			logged = entry
+		}
+	}
+	if logged == nil {
+		t.Fatalf("Expected the member changes to be logged, got %v", entries)
+	}
+	if logged["@level"] != "info" || logged["team_id"] != created.ID.ValueString() {
+		t.Errorf("Expected an info entry for team %s, got %v", created.ID.ValueString(), logged)
+	}
+	for field, want := range map[string]string{"added": "[c]", "removed": "[b]", "failed": "[bad]"} {
+		if got := fmt.Sprint(logged[field]); got != want {
+			t.Errorf("Expected %s %s, got %s", field, want, got)
+		}
+	}
+	if logged["added_count"] != float64(1) || logged["removed_count"] != float64(1) {
+		t.Errorf("Expected one added and one removed member, got %v", logged)
+	}
+
+	// Nothing is logged without changes
+	output.Reset()
+	call.resource.syncTeamMembers(ctx, &created, desired, desired, &diags)
+	if output.Len() != 0 {
+		t.Errorf("Expected no log output without member changes, got %s", output.String())
+	}
+}
+
+func TestTeamResourceKeepsMemberTypes(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "EXTERNAL")
+	planned.Members = testMembersSet(t, "a")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	// Membership of EXTERNAL teams is synced by the identity provider
+	if _, err := client.AddTeamMembers("org-1", created.ID.ValueString(), []TeamMember{{AccountID: "a"}}); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	// Member types are not returned by the API, they are carried over from state
+	typed := created
+	typed.Members = teamMembersToSet([]TeamMember{{AccountID: "a", MemberType: "EXTERNAL"}}, nil)
+	updated, diags := call.update(created, typed)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if !updated.Members.Equal(typed.Members) {
+		t.Errorf("Expected member types to be applied, got %v", updated.Members)
+	}
+
+	read, _, diags := call.read(updated)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if !read.Members.Equal(typed.Members) {
+		t.Errorf("Expected member types to survive a read, got %v", read.Members)
+	}
+}
+
+func TestTeamResourceModifyPlanSummarizesMemberChanges(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+	ctx := context.Background()
+
+	prior := testTeamResourceModel("Platform", "", "OPEN")
+	prior.ID = types.StringValue("team-1")
+	prior.Members = testMembersSet(t, "a", "b")
+	planned := prior
+	planned.Members = testMembersSet(t, "b", "c", "d")
+
+	resp := &resource.ModifyPlanResponse{Plan: call.plan(planned)}
+	call.resource.ModifyPlan(ctx, resource.ModifyPlanRequest{State: call.state(prior), Plan: call.plan(planned)}, resp)
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.HasError() {
+		t.Fatalf("Expected one summary warning, got: %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, "adds 2 and removes 1") {
+		t.Errorf("Expected the summary to count 2 additions and 1 removal, got %q", detail)
+	}
+
+	resp = &resource.ModifyPlanResponse{Plan: call.plan(prior)}
+	call.resource.ModifyPlan(ctx, resource.ModifyPlanRequest{State: call.state(prior), Plan: call.plan(prior)}, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics without member changes, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestTeamResourceKeepsLabelsInState(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Labels = types.MapValueMust(types.StringType, map[string]attr.Value{"cost_center": types.StringValue("eng")})
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	read, _, diags := call.read(created)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if !read.Labels.Equal(planned.Labels) {
+		t.Errorf("Expected labels %v to be kept by Read, got %v", planned.Labels, read.Labels)
+	}
+}
+
+func TestTeamResourceUpdateSkipsWhitespaceOnlyChanges(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "Core services", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	planned := created
+	planned.Description = types.StringValue("Core services ")
+	updated, diags := call.update(created, planned)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if got := len(fake.requestsMatching("PATCH")); got != 0 {
+		t.Errorf("Expected no PATCH for a trailing-space-only change, got %d", got)
+	}
+	if updated.Description.ValueString() != "Core services " || updated.State != created.State {
+		t.Errorf("Expected the configured description and prior computed values, got %+v", updated)
+	}
+
+	read, _, diags := call.read(updated)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if read.Description.ValueString() != "Core services " {
+		t.Errorf("Expected Read not to report the trimmed description as drift, got %q", read.Description.ValueString())
+	}
+}
+
+func TestTeamResourceImportState(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		importID, wantID, wantOrg string
+		wantErr                   bool
+	}{
+		{importID: "team-1", wantID: "team-1"},
+		{importID: "org-2/team-1", wantID: "team-1", wantOrg: "org-2"},
+		{importID: "", wantErr: true},
+		{importID: "org-2/", wantErr: true},
+		{importID: "/team-1", wantErr: true},
+		{importID: "org-2/team-1/extra", wantErr: true},
+		{importID: "org 2/team-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		resp := &resource.ImportStateResponse{State: call.emptyState()}
+		newPrivateState(&resp.Private)
+		call.resource.ImportState(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+		if tt.wantErr {
+			if !resp.Diagnostics.HasError() {
+				t.Errorf("%q: expected an error", tt.importID)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%q: ImportState failed: %v", tt.importID, resp.Diagnostics)
+		}
+
+		var id, org types.String
+		resp.State.GetAttribute(ctx, path.Root("id"), &id)
+		resp.State.GetAttribute(ctx, path.Root("organization_id"), &org)
+		if id.ValueString() != tt.wantID || org.ValueString() != tt.wantOrg {
+			t.Errorf("%q: expected id %q and organization_id %q, got %v and %v", tt.importID, tt.wantID, tt.wantOrg, id, org)
+		}
+	}
+}
+
+func TestTeamResourceReadImportedTeamOfAnotherOrg(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	team, err := client.WithOrgId("org-2").CreateTeam(&CreateTeamRequest{DisplayName: "Platform", TeamType: "OPEN"})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	resp := &resource.ImportStateResponse{State: call.emptyState()}
+	newPrivateState(&resp.Private)
+	call.resource.ImportState(context.Background(), resource.ImportStateRequest{ID: "org-2/" + team.TeamID}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState failed: %v", resp.Diagnostics)
+	}
+	call.privates[team.TeamID] = resp.Private
+
+	read, found, diags := call.read(call.model(resp.State))
+	if diags.HasError() || !found {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if read.OrganizationId.ValueString() != "org-2" || read.DisplayName.ValueString() != "Platform" {
+		t.Errorf("Expected the team of org-2, got %+v", read)
+	}
+	if got := fake.requestsMatching("/org/org-2/teams/" + team.TeamID); len(got) == 0 {
+		t.Error("Expected the team to be read from org-2")
+	}
+}
+
+func TestTeamResourceUpdateDistinguishesEmptyAndUnmanagedMembers(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "b")
+	planned.ManageAllMembers = types.BoolValue(true)
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	teamID := created.ID.ValueString()
+
+	// Members left out of the configuration are planned as unknown and not touched
+	unmanaged := created
+	unmanaged.Members = types.SetUnknown(teamMemberObjectType)
+	updated, diags := call.update(created, unmanaged)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if got := fake.teamMembers(teamID); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected unmanaged members to be left alone, got %v", got)
+	}
+	if !updated.Members.Equal(created.Members) {
+		t.Errorf("Expected the prior members in state, got %v", updated.Members)
+	}
+
+	// An explicit empty set with authoritative management removes everyone
+	empty := created
+	empty.Members = testMembersSet(t)
+	updated, diags = call.update(created, empty)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if got := fake.teamMembers(teamID); len(got) != 0 {
+		t.Errorf("Expected all members to be removed, got %v", got)
+	}
+	if len(updated.Members.Elements()) != 0 || updated.Members.IsNull() {
+		t.Errorf("Expected an empty members set in state, got %v", updated.Members)
+	}
+}
+
+func TestTeamResourceDisplayNameValidation(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+	attribute := call.schema.Attributes["display_name"].(schema.StringAttribute)
+
+	tests := map[string]bool{
+		"Platform":   false,
+		" Platform ": false,
+		"":           true,
+		"   ":        true,
+		"\t\n":       true,
+	}
+	for name, wantErr := range tests {
+		resp := &validator.StringResponse{}
+		for _, v := range attribute.Validators {
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("display_name"),
+				ConfigValue: types.StringValue(name),
+			}, resp)
+		}
+		if got := resp.Diagnostics.HasError(); got != wantErr {
+			t.Errorf("display_name %q: expected error %v, got %v", name, wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTeamResourceReadKeepsMissingTeamWhenConfigured(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	fake.mu.Lock()
+	delete(fake.teams, created.ID.ValueString())
+	fake.mu.Unlock()
+
+	client.TreatMissingAsDeleted = false
+	_, exists, diags := call.read(created)
+	if !diags.HasError() || !exists {
+		t.Errorf("Expected a 404 to fail the refresh and keep the team in state, got exists=%v diags=%v", exists, diags)
+	}
+}
+
+func TestTeamResourceReadChecksExpectedOrganization(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.ExpectedOrganizationId = types.StringValue("org-1")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, _, diags := call.read(created); diags.HasError() {
+		t.Fatalf("Read failed for the expected organization: %v", diags)
+	}
+
+	created.ExpectedOrganizationId = types.StringValue("org-2")
+	if _, _, diags := call.read(created); !diags.HasError() {
+		t.Error("Expected Read to fail for a team of another organization than expected_organization_id")
+	}
+}
+
+func TestTeamResourceDeleteRetriesConflicts(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	fake.mu.Lock()
+	fake.deleteConflicts = 2
+	fake.mu.Unlock()
+	created.RetryDeleteConflicts = types.BoolValue(false)
+	if diags := call.delete(created); !diags.HasError() {
+		t.Fatal("Expected a conflict to fail the delete with retry_delete_conflicts = false")
+	}
+
+	// Retrying is the default
+	created.RetryDeleteConflicts = types.BoolValue(true)
+	if diags := call.delete(created); diags.HasError() {
+		t.Fatalf("Expected the conflict to be retried, got: %v", diags)
+	}
+	if fake.team(created.ID.ValueString()) != nil {
+		t.Error("Expected the team to be deleted")
+	}
+}
+
+func TestTeamResourceUpdateChangesMemberAttributesInPlace(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a", "b")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	calls := len(fake.requestsMatching("/members/"))
+
+	// member_type stands in for per-member settings such as roles, which the API does not have
+	changed := created
+	changed.Members = teamMembersToSet([]TeamMember{{AccountID: "a", MemberType: "EXTERNAL"}, {AccountID: "b"}}, nil)
+	updated, diags := call.update(created, changed)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if !updated.Members.Equal(changed.Members) {
+		t.Errorf("Expected members %v in state, got %v", changed.Members, updated.Members)
+	}
+	if got := fake.requestsMatching("/members/"); len(got) != calls {
+		t.Errorf("Expected no member to be removed and re-added, got requests %v", got[calls:])
+	}
+	if !slices.Equal(fake.teamMembers(created.ID.ValueString()), []string{"a", "b"}) {
+		t.Errorf("Expected members a and b to be kept, got %v", fake.teamMembers(created.ID.ValueString()))
+	}
+}
+
+func TestTeamResourceImportLeavesUnlistedMembersAlone(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	members := []TeamMember{{AccountID: "a"}, {AccountID: "b"}, {AccountID: "c"}}
+	if _, err := client.AddTeamMembers("org-1", created.ID.ValueString(), members); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+	fetches := len(fake.requestsMatching("/members"))
+
+	// An import only knows the team ID, members stay unmanaged until configured
+	imported := created
+	imported.Members = types.SetNull(teamMemberObjectType)
+	read, _, diags := call.read(imported)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if !read.Members.IsNull() || len(fake.requestsMatching("/members")) != fetches {
+		t.Errorf("Expected the refresh after import not to read members, got %v", read.Members)
+	}
+
+	planned := read
+	planned.Members = testMembersSet(t, "a")
+	updated, diags := call.update(read, planned)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if !updated.Members.Equal(planned.Members) || len(fake.requestsMatching("/members/remove")) != 0 {
+		t.Errorf("Expected only the listed member to be managed, got %v", updated.Members)
+	}
+	if got := fake.teamMembers(created.ID.ValueString()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Expected unlisted members to be left alone, got %v", got)
+	}
+}
+
+func TestTeamResourceRawJSON(t *testing.T) {
+	_, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	created, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if !created.RawJSON.IsNull() {
+		t.Errorf("Expected no raw_json unless expose_raw_json is set, got %v", created.RawJSON)
+	}
+
+	client.ExposeRawJSON = true
+	read, _, diags := call.read(created)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(read.RawJSON.ValueString()), &raw); err != nil || raw["teamId"] != created.ID.ValueString() {
+		t.Errorf("Expected raw_json to hold the team response, got %v (%v)", read.RawJSON, err)
+	}
+}
+
+func TestTeamResourceUpgradeStateV0(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+
+	// Version 0 state of an early provider version, with a since removed attribute
+	rawState := &tfprotov6.RawState{JSON: []byte(`{
+		"id": "team-1",
+		"display_name": "Platform",
+		"description": "Platform team",
+		"team_type": "OPEN",
+		"organization_id": "org-1",
+		"members": null,
+		"removed_attribute": "ignored"
+	}`)}
+
+	upgrader, ok := call.resource.UpgradeState(context.Background())[0]
+	if !ok {
+		t.Fatal("Expected a state upgrader for version 0")
+	}
+	resp := &resource.UpgradeStateResponse{State: call.emptyState()}
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{RawState: rawState}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState failed: %v", resp.Diagnostics)
+	}
+
+	data := call.model(resp.State)
+	if data.ID.ValueString() != "team-1" || data.DisplayName.ValueString() != "Platform" || data.Description.ValueString() != "Platform team" {
+		t.Errorf("Expected the version 0 attributes to be kept, got %+v", data)
+	}
+	if !data.State.IsNull() || !data.RawJSON.IsNull() || !data.Labels.IsNull() || !data.ExpectedOrganizationId.IsNull() {
+		t.Errorf("Expected attributes missing from version 0 to be null, got %+v", data)
+	}
+	if data.DeletionPolicy.ValueString() != "delete" || data.ForceDelete.IsNull() || data.ForceDelete.ValueBool() || !data.RetryDeleteConflicts.ValueBool() {
+		t.Errorf("Expected attributes with a default to get it, got deletion_policy %v, force_delete %v and retry_delete_conflicts %v",
+			data.DeletionPolicy, data.ForceDelete, data.RetryDeleteConflicts)
+	}
+}
+
+func TestTeamResourceReadExplainsForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code":"FORBIDDEN","message":"Insufficient permissions"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org-1", server.URL)
+	call := newTestTeamResourceCall(t, client)
+
+	data := testTeamResourceModel("Platform", "", "OPEN")
+	data.ID = types.StringValue("team-1")
+	_, _, diags := call.read(data)
+	if !diags.HasError() {
+		t.Fatal("Expected a 403 to fail the refresh")
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "read:team:atlassian") {
+		t.Errorf("Expected the error to explain the missing permission, got %q", detail)
+	}
+}
+
+func TestAddTeamClientErrorTargetsAttribute(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want path.Path
+	}{
+		"display name too long": {
+			err:  &APIError{StatusCode: http.StatusBadRequest, Body: `{"code":"BAD_REQUEST","message":"displayName must be at most 250 characters"}`},
+			want: path.Root("display_name"),
+		},
+		"invalid team type": {
+			err:  &APIError{StatusCode: http.StatusBadRequest, Body: `{"code":"BAD_REQUEST","message":"teamType is invalid"}`},
+			want: path.Root("team_type"),
+		},
+		"unrelated bad request": {
+			err: &APIError{StatusCode: http.StatusBadRequest, Body: `{"code":"BAD_REQUEST","message":"malformed request"}`},
+		},
+		"server error naming a field": {
+			err: &APIError{StatusCode: http.StatusInternalServerError, Body: `displayName`},
+		},
+	}
+	for name, tt := range tests {
+		var diags diag.Diagnostics
+		addTeamClientError(&diags, "detail", tt.err)
+
+		var got path.Path
+		if withPath, ok := diags[0].(diag.DiagnosticWithPath); ok {
+			got = withPath.Path()
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: expected path %v, got %v", name, tt.want, got)
+		}
+	}
+}
+
+func TestTeamResourceCreateAttributesAPIValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":"BAD_REQUEST","message":"displayName must be at most 250 characters"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org-1", server.URL)
+	call := newTestTeamResourceCall(t, client)
+
+	_, diags := call.create(testTeamResourceModel("Platform", "", "OPEN"))
+	if !diags.HasError() {
+		t.Fatal("Expected the rejected create to fail")
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("display_name")) {
+		t.Errorf("Expected the error on display_name, got %v", diags)
+	}
+}
+
+func TestTeamResourceArchivedTeamMemberChanges(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+	ctx := context.Background()
+
+	planned := testTeamResourceModel("Platform", "", "OPEN")
+	planned.Members = testMembersSet(t, "a")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if _, err := client.ArchiveTeams("org-1", []string{created.ID.ValueString()}); err != nil {
+		t.Fatalf("ArchiveTeams failed: %v", err)
+	}
+	archived, _, diags := call.read(created)
+	if diags.HasError() || archived.State.ValueString() != "ARCHIVED" {
+		t.Fatalf("Expected the read to record the archived state, got %v (%v)", archived.State, diags)
+	}
+
+	// Without auto_unarchive, member changes are refused at plan time
+	planned = archived
+	planned.Members = testMembersSet(t, "a", "b")
+	resp := &resource.ModifyPlanResponse{Plan: call.plan(planned)}
+	call.resource.ModifyPlan(ctx, resource.ModifyPlanRequest{State: call.state(archived), Plan: call.plan(planned)}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected member changes to an archived team to be refused")
+	}
+	if withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("members")) {
+		t.Errorf("Expected the error on members, got %v", resp.Diagnostics)
+	}
+
+	// With auto_unarchive, the plan unarchives the team before the members are added
+	planned.AutoUnarchive = types.BoolValue(true)
+	resp = &resource.ModifyPlanResponse{Plan: call.plan(planned)}
+	call.resource.ModifyPlan(ctx, resource.ModifyPlanRequest{State: call.state(archived), Plan: call.plan(planned)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan failed: %v", resp.Diagnostics)
+	}
+	var modified TeamResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &modified)...)
+	if modified.State.ValueString() != "ACTIVE" {
+		t.Fatalf("Expected the plan to unarchive the team, got state %v", modified.State)
+	}
+
+	updated, diags := call.update(archived, modified)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if updated.State.ValueString() != "ACTIVE" || fake.team(created.ID.ValueString()).State != "ACTIVE" {
+		t.Errorf("Expected the team to be unarchived, got state %v", updated.State)
+	}
+	if got := fake.teamMembers(created.ID.ValueString()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected members [a b], got %v", got)
+	}
+}
+
+func TestTeamResourceUpdateTeamTypeRecreatesTeam(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+	ctx := context.Background()
+
+	planned := testTeamResourceModel("Platform", "Platform team", "OPEN")
+	planned.Members = testMembersSet(t, "a")
+	created, diags := call.create(planned)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	// An unmanaged member is carried over as well
+	if _, err := client.AddTeamMembers("org-1", created.ID.ValueString(), []TeamMember{{AccountID: "b"}}); err != nil {
+		t.Fatalf("AddTeamMembers failed: %v", err)
+	}
+
+	planned = created
+	planned.TeamType = types.StringValue("MEMBER_INVITE")
+	resp := &resource.ModifyPlanResponse{Plan: call.plan(planned)}
+	call.resource.ModifyPlan(ctx, resource.ModifyPlanRequest{State: call.state(created), Plan: call.plan(planned)}, resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a recreate warning, got %v", resp.Diagnostics)
+	}
+	var modified TeamResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &modified)...)
+	if !modified.ID.IsUnknown() {
+		t.Errorf("Expected the planned id to be unknown, got %v", modified.ID)
+	}
+
+	updated, diags := call.update(created, modified)
+	if diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if updated.ID.Equal(created.ID) || updated.TeamType.ValueString() != "MEMBER_INVITE" {
+		t.Errorf("Expected a new MEMBER_INVITE team, got id %v and type %v", updated.ID, updated.TeamType)
+	}
+	if got := fake.teamMembers(updated.ID.ValueString()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected members [a b] on the new team, got %v", got)
+	}
+	if fake.team(created.ID.ValueString()) != nil {
+		t.Errorf("Expected the old team %s to be deleted", created.ID.ValueString())
+	}
+	if !updated.Members.Equal(testMembersSet(t, "a")) {
+		t.Errorf("Expected only the listed member in state, got %v", updated.Members)
+	}
+}
+
+func TestTeamResourceUpdateTeamTypeRetiresOldTeamLikeDestroy(t *testing.T) {
+	fake, client := newFakeAtlassianServer(t, "org-1")
+	call := newTestTeamResourceCall(t, client)
+
+	tests := []struct {
+		name           string
+		deletionPolicy string
+		skipDestroy    bool
+		wantState      string
+	}{
+		{"archive", "archive", false, "ARCHIVED"},
+		{"skip_destroy", "delete", true, "ACTIVE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planned := testTeamResourceModel("Platform "+tt.name, "", "OPEN")
+			planned.DeletionPolicy = types.StringValue(tt.deletionPolicy)
+			planned.SkipDestroy = types.BoolValue(tt.skipDestroy)
+			created, diags := call.create(planned)
+			if diags.HasError() {
+				t.Fatalf("Create failed: %v", diags)
+			}
+
+			planned = created
+			planned.ID = types.StringUnknown()
+			planned.TeamType = types.StringValue("MEMBER_INVITE")
+			updated, diags := call.update(created, planned)
+			if diags.HasError() {
+				t.Fatalf("Update failed: %v", diags)
+			}
+			if updated.ID.Equal(created.ID) {
+				t.Errorf("Expected a new team, got id %v", updated.ID)
+			}
+			old := fake.team(created.ID.ValueString())
+			if old == nil || old.State != tt.wantState {
+				t.Errorf("Expected the old team to be kept in state %s, got %+v", tt.wantState, old)
+			}
+
+			// The old team was replaced rather than removed from state
+			if tt.skipDestroy {
+				warnings := diags.Warnings()
+				if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "left in place after team "+updated.ID.ValueString()+" replaced it") {
+					t.Errorf("Expected a warning about the replaced team, got %v", warnings)
+				}
+			}
+		})
+	}
+}
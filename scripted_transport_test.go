@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scriptedResponse is one step of a scriptedTransport: a response with status,
+// headers and body, or err to fail the round trip
+type scriptedResponse struct {
+	status int
+	header http.Header
+	body   string
+	err    error
+}
+
+// scriptedTransport is an http.RoundTripper that answers requests with a
+// fixed sequence of responses, so retry tests need no server
+type scriptedTransport struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	responses []scriptedResponse
+	requests  []*http.Request
+}
+
+func (s *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+	if len(s.responses) == 0 {
+		s.t.Errorf("Unexpected request %s %s after the scripted responses", req.Method, req.URL.Path)
+		return nil, io.ErrUnexpectedEOF
+	}
+	next := s.responses[0]
+	s.responses = s.responses[1:]
+
+	if next.err != nil {
+		return nil, next.err
+	}
+	header := next.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode:    next.status,
+		Status:        http.StatusText(next.status),
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(next.body)),
+		ContentLength: int64(len(next.body)),
+		Request:       req,
+	}, nil
+}
+
+// recordedSleeps collects the waits of a client instead of sleeping
+type recordedSleeps struct {
+	mu    sync.Mutex
+	waits []time.Duration
+}
+
+func (r *recordedSleeps) sleep(ctx context.Context, d time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.waits = append(r.waits, d)
+	return ctx.Err()
+}
+
+// newScriptedClient returns a client whose requests are answered by
+// responses and whose backoff waits are recorded instead of slept. Jitter is
+// disabled, so waits are the exact backoff ceilings.
+func newScriptedClient(t *testing.T, responses ...scriptedResponse) (*AtlassianClient, *scriptedTransport, *recordedSleeps) {
+	t.Helper()
+
+	transport := &scriptedTransport{t: t, responses: responses}
+	sleeps := &recordedSleeps{}
+
+	client, _ := NewAtlassianClient("token", "", "", "", "org", "https://api.example.test")
+	client.HTTPClient = &http.Client{Transport: transport}
+	client.jitter = nil
+	client.sleeper = sleeps.sleep
+
+	t.Cleanup(func() {
+		transport.mu.Lock()
+		defer transport.mu.Unlock()
+		if len(transport.responses) > 0 {
+			t.Errorf("Expected all scripted responses to be used, %d left", len(transport.responses))
+		}
+	})
+	return client, transport, sleeps
+}
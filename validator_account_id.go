@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	// accountIdPrefixedPattern matches account IDs of the form <prefix>:<uuid>
+	accountIdPrefixedPattern = regexp.MustCompile(`^[0-9A-Za-z]+:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	// accountIdOpaquePattern matches 24 character opaque account IDs
+	accountIdOpaquePattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+)
+
+var _ validator.String = accountIdValidator{}
+
+// accountIdValidator rejects values that cannot be Atlassian account IDs, such
+// as emails, and warns about values in an unfamiliar format. Unfamiliar formats
+// are not rejected so that future account ID formats keep working.
+type accountIdValidator struct{}
+
+func (v accountIdValidator) Description(ctx context.Context) string {
+	return "value must be an Atlassian account ID, not an email address or username"
+}
+
+func (v accountIdValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v accountIdValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	accountId := req.ConfigValue.ValueString()
+
+	if strings.Contains(accountId, "@") || strings.IndexFunc(accountId, unicode.IsSpace) >= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Atlassian Account ID",
+			fmt.Sprintf("%q is not an Atlassian account ID. Account IDs cannot contain '@' or whitespace; use the account ID "+
+				"(e.g. 557058:f58131cb-b67d-43c7-b30d-6b58d40bd077) rather than an email address or username.", accountId),
+		)
+		return
+	}
+
+	if !accountIdPrefixedPattern.MatchString(accountId) && !accountIdOpaquePattern.MatchString(accountId) {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Unrecognized Atlassian Account ID Format",
+			fmt.Sprintf("%q does not look like an Atlassian account ID, which usually have the form <prefix>:<uuid> or are 24 characters long. "+
+				"The API may reject it.", accountId),
+		)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAccountIdValidator(t *testing.T) {
+	tests := map[string]struct {
+		value       string
+		wantError   bool
+		wantWarning bool
+	}{
+		"prefixed uuid": {value: "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"},
+		"opaque":        {value: "5b10ac8d82e05b22cc7d4ef5"},
+		"email":         {value: "user@example.com", wantError: true},
+		"with space":    {value: "557058 f58131cb", wantError: true},
+		"username":      {value: "jdoe", wantWarning: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("members"),
+				ConfigValue: types.StringValue(tt.value),
+			}
+			resp := &validator.StringResponse{}
+
+			accountIdValidator{}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error=%v, got: %v", tt.wantError, resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != tt.wantWarning {
+				t.Errorf("Expected warning=%v, got: %v", tt.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
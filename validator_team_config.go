@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ConfigValidator = teamMembersTypeValidator{}
+var _ resource.ConfigValidator = teamMemberTypeValidator{}
+var _ resource.ConfigValidator = teamEmptyMembersValidator{}
+var _ resource.ConfigValidator = teamExpectedOrganizationValidator{}
+
+// teamMembersTypeValidator rejects members on ORG_ADMIN_MANAGED teams at plan
+// time, instead of letting the membership endpoints reject them on apply
+type teamMembersTypeValidator struct{}
+
+func (v teamMembersTypeValidator) Description(ctx context.Context) string {
+	return "members must not be set when team_type is ORG_ADMIN_MANAGED"
+}
+
+func (v teamMembersTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return "`members` must not be set when `team_type` is `ORG_ADMIN_MANAGED`"
+}
+
+func (v teamMembersTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var teamType types.String
+	var members types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("team_type"), &teamType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("members"), &members)...)
+
+	if teamType.ValueString() != "ORG_ADMIN_MANAGED" || members.IsNull() || members.IsUnknown() || len(members.Elements()) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("members"),
+		"Members Not Supported For ORG_ADMIN_MANAGED Teams",
+		"The membership of ORG_ADMIN_MANAGED teams can only be changed by organization admins in Atlassian Administration, "+
+			"so the Teams API rejects member changes for them. Remove members from this resource or use another team_type.",
+	)
+}
+
+// teamMemberTypeValidator only allows member_type on EXTERNAL teams. The API
+// has no member types, so on other teams the value would silently do nothing.
+type teamMemberTypeValidator struct{}
+
+func (v teamMemberTypeValidator) Description(ctx context.Context) string {
+	return "members[*].member_type may only be set when team_type is EXTERNAL"
+}
+
+func (v teamMemberTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return "`members[*].member_type` may only be set when `team_type` is `EXTERNAL`"
+}
+
+func (v teamMemberTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var teamType types.String
+	var members types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("team_type"), &teamType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("members"), &members)...)
+
+	// An unset team_type falls back to the provider default, which is only known on apply
+	if teamType.IsNull() || teamType.IsUnknown() || teamType.ValueString() == "EXTERNAL" || members.IsNull() || members.IsUnknown() {
+		return
+	}
+
+	var models []TeamMemberModel
+	resp.Diagnostics.Append(members.ElementsAs(ctx, &models, false)...)
+	for _, model := range models {
+		if model.MemberType.IsNull() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("members"),
+			"Member Type Not Supported",
+			"The Teams API does not distinguish internal and external members, so member_type is only accepted on EXTERNAL teams, "+
+				"where it documents guests synced from the identity provider. Remove member_type from the members of this "+teamType.ValueString()+" team.",
+		)
+		return
+	}
+}
+
+// teamEmptyMembersValidator only allows an empty members set together with
+// manage_all_members. A null set leaves membership unmanaged, an empty one
+// removes every member, which only authoritative management does.
+type teamEmptyMembersValidator struct{}
+
+func (v teamEmptyMembersValidator) Description(ctx context.Context) string {
+	return "members may only be an empty set when manage_all_members is true"
+}
+
+func (v teamEmptyMembersValidator) MarkdownDescription(ctx context.Context) string {
+	return "`members` may only be an empty set when `manage_all_members` is `true`"
+}
+
+func (v teamEmptyMembersValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var manageAllMembers types.Bool
+	var members types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("manage_all_members"), &manageAllMembers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("members"), &members)...)
+
+	if members.IsNull() || members.IsUnknown() || len(members.Elements()) > 0 || manageAllMembers.IsUnknown() || manageAllMembers.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("members"),
+		"Empty Members Without manage_all_members",
+		"An empty members set only manages members when manage_all_members is true, where it removes every member of the team. "+
+			"Set manage_all_members = true to remove all members, or leave members out to not manage membership.",
+	)
+}
+
+// teamExpectedOrganizationValidator rejects an expected_organization_id that
+// contradicts organization_id at plan time. Read would otherwise only refuse
+// the team after it was created in organization_id.
+type teamExpectedOrganizationValidator struct{}
+
+func (v teamExpectedOrganizationValidator) Description(ctx context.Context) string {
+	return "expected_organization_id must match organization_id when both are set"
+}
+
+func (v teamExpectedOrganizationValidator) MarkdownDescription(ctx context.Context) string {
+	return "`expected_organization_id` must match `organization_id` when both are set"
+}
+
+func (v teamExpectedOrganizationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var organizationId, expected types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("organization_id"), &organizationId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expected_organization_id"), &expected)...)
+
+	// Values only known on apply are checked by Read
+	if organizationId.IsNull() || organizationId.IsUnknown() || expected.IsNull() || expected.IsUnknown() || organizationId.Equal(expected) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("expected_organization_id"),
+		"Conflicting Organization IDs",
+		"The team is managed in organization "+organizationId.ValueString()+", but expected_organization_id is "+expected.ValueString()+
+			", so every refresh would refuse it. Set both attributes to the same organization or remove one of them.",
+	)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTeamMembersTypeValidator(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+
+	tests := []struct {
+		teamType string
+		members  types.Set
+		wantErr  bool
+	}{
+		{"ORG_ADMIN_MANAGED", testMembersSet(t, "a"), true},
+		{"ORG_ADMIN_MANAGED", testMembersSet(t), false},
+		{"ORG_ADMIN_MANAGED", types.SetNull(teamMemberObjectType), false},
+		{"OPEN", testMembersSet(t, "a"), false},
+	}
+	for _, tt := range tests {
+		data := testTeamResourceModel("Platform", "", tt.teamType)
+		data.Members = tt.members
+
+		resp := &resource.ValidateConfigResponse{}
+		config := tfsdk.Config{Schema: call.schema, Raw: call.plan(data).Raw}
+		teamMembersTypeValidator{}.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+			t.Errorf("%s with members %v: expected error %v, got %v", tt.teamType, tt.members, tt.wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTeamMemberTypeValidator(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+	guests := teamMembersToSet([]TeamMember{{AccountID: "a", MemberType: "EXTERNAL"}}, nil)
+
+	tests := []struct {
+		teamType types.String
+		members  types.Set
+		wantErr  bool
+	}{
+		{types.StringValue("EXTERNAL"), guests, false},
+		{types.StringValue("OPEN"), guests, true},
+		{types.StringValue("OPEN"), testMembersSet(t, "a"), false},
+		{types.StringNull(), guests, false},
+	}
+	for _, tt := range tests {
+		data := testTeamResourceModel("Platform", "", "")
+		data.TeamType = tt.teamType
+		data.Members = tt.members
+
+		resp := &resource.ValidateConfigResponse{}
+		config := tfsdk.Config{Schema: call.schema, Raw: call.plan(data).Raw}
+		teamMemberTypeValidator{}.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+			t.Errorf("%v with members %v: expected error %v, got %v", tt.teamType, tt.members, tt.wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTeamEmptyMembersValidator(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+
+	tests := []struct {
+		members          types.Set
+		manageAllMembers types.Bool
+		wantErr          bool
+	}{
+		{testMembersSet(t), types.BoolValue(false), true},
+		{testMembersSet(t), types.BoolNull(), true},
+		{testMembersSet(t), types.BoolValue(true), false},
+		{types.SetNull(teamMemberObjectType), types.BoolValue(false), false},
+		{testMembersSet(t, "a"), types.BoolValue(false), false},
+	}
+	for _, tt := range tests {
+		data := testTeamResourceModel("Platform", "", "OPEN")
+		data.Members = tt.members
+		data.ManageAllMembers = tt.manageAllMembers
+
+		resp := &resource.ValidateConfigResponse{}
+		config := tfsdk.Config{Schema: call.schema, Raw: call.plan(data).Raw}
+		teamEmptyMembersValidator{}.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+			t.Errorf("members %v with manage_all_members %v: expected error %v, got %v", tt.members, tt.manageAllMembers, tt.wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTeamExpectedOrganizationValidator(t *testing.T) {
+	call := newTestTeamResourceCall(t, nil)
+
+	tests := []struct {
+		organizationId types.String
+		expected       types.String
+		wantErr        bool
+	}{
+		{types.StringValue("org-1"), types.StringValue("org-2"), true},
+		{types.StringValue("org-1"), types.StringValue("org-1"), false},
+		{types.StringNull(), types.StringValue("org-2"), false},
+		{types.StringValue("org-1"), types.StringNull(), false},
+		{types.StringUnknown(), types.StringValue("org-2"), false},
+	}
+	for _, tt := range tests {
+		data := testTeamResourceModel("Platform", "", "OPEN")
+		data.OrganizationId = tt.organizationId
+		data.ExpectedOrganizationId = tt.expected
+
+		resp := &resource.ValidateConfigResponse{}
+		config := tfsdk.Config{Schema: call.schema, Raw: call.plan(data).Raw}
+		teamExpectedOrganizationValidator{}.ValidateResource(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+		if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+			t.Errorf("organization_id %v with expected_organization_id %v: expected error %v, got %v", tt.organizationId, tt.expected, tt.wantErr, resp.Diagnostics)
+		}
+	}
+}